@@ -0,0 +1,204 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/appinesshq/globire-go/uk/ch/api"
+)
+
+// command is a subcommand of chq, returning the API response and its tabular representation
+type command struct {
+	name string
+	arg  string
+	help string
+	run  func(a *api.API, arg string, opts options) (interface{}, table, error)
+}
+
+var commands = []command{
+	{"company", "number", "company profile", companyCmd},
+	{"officers", "number", "officers of a company", officersCmd},
+	{"filings", "number", "filing history of a company", filingsCmd},
+	{"charges", "number", "charges of a company", chargesCmd},
+	{"psc", "number", "persons with significant control of a company", pscCmd},
+	{"search", "query", "search for companies", searchCmd},
+	{"appointments", "officer id", "appointments of an officer", appointmentsCmd},
+}
+
+// paging returns the paging options set by the flags
+func paging(opts options) []api.Option {
+	var res []api.Option
+	if opts.limit > 0 {
+		res = append(res, api.ItemsPerPage(opts.limit))
+	}
+	if opts.start > 0 {
+		res = append(res, api.StartIndex(opts.start))
+	}
+	return res
+}
+
+func companyCmd(a *api.API, number string, opts options) (interface{}, table, error) {
+	c, err := a.GetCompany(number)
+	if err != nil {
+		return nil, table{}, err
+	}
+
+	sic := make([]string, len(c.SICCodes))
+	for i, s := range c.SICCodes {
+		sic[i] = s.String()
+	}
+
+	t := table{header: []string{"field", "value"}, rows: [][]string{
+		{"Name", c.Name},
+		{"Number", c.CompanyNumber},
+		{"Status", c.CompanyStatus.String()},
+		{"Type", c.Type.String()},
+		{"Jurisdiction", c.Jurisdiction.String()},
+		{"Created", date(c.DateOfCreation)},
+		{"Ceased", date(c.DateOfCessation)},
+		{"Registered office", address(c.RegisteredOfficeAddress)},
+		{"SIC codes", strings.Join(sic, "; ")},
+		{"Accounts due", date(c.Accounts.NextAccounts.DueOn)},
+		{"Confirmation statement due", date(c.ConfirmationStatement.NextDue)},
+		{"Has charges", strconv.FormatBool(c.HasCharges)},
+		{"Has insolvency history", strconv.FormatBool(c.HasInsolvencyHistory)},
+	}}
+	return c, t, nil
+}
+
+func officersCmd(a *api.API, number string, opts options) (interface{}, table, error) {
+	c, err := a.GetCompany(number)
+	if err != nil {
+		return nil, table{}, err
+	}
+
+	res, err := c.Officers(paging(opts)...)
+	if err != nil {
+		return nil, table{}, err
+	}
+
+	t := table{header: []string{"name", "role", "appointed", "resigned", "nationality", "id"}}
+	for _, o := range res.Items {
+		t.rows = append(t.rows, []string{o.Name, o.OfficerRole.String(), date(o.AppointedOn), date(o.ResignedOn), o.Nationality, o.ID()})
+	}
+	return res, t, nil
+}
+
+func filingsCmd(a *api.API, number string, opts options) (interface{}, table, error) {
+	c, err := a.GetCompany(number)
+	if err != nil {
+		return nil, table{}, err
+	}
+
+	options := paging(opts)
+	if opts.category != "" {
+		options = append(options, api.Category(strings.Split(opts.category, ",")...))
+	}
+
+	res, err := c.FilingHistory(options...)
+	if err != nil {
+		return nil, table{}, err
+	}
+
+	t := table{header: []string{"date", "type", "category", "description"}}
+	for _, f := range res.Items {
+		// Missing values remain as placeholders, which is still useful in a listing
+		desc, _ := f.RenderDescription(api.PlainText)
+		t.rows = append(t.rows, []string{date(f.Date), f.Type, f.Category, desc})
+	}
+	return res, t, nil
+}
+
+func chargesCmd(a *api.API, number string, opts options) (interface{}, table, error) {
+	c, err := a.GetCompany(number)
+	if err != nil {
+		return nil, table{}, err
+	}
+
+	res, err := c.Charges(paging(opts)...)
+	if err != nil {
+		return nil, table{}, err
+	}
+
+	t := table{header: []string{"code", "status", "created", "satisfied", "persons entitled"}}
+	for _, ch := range res.Items {
+		var persons []string
+		for _, p := range ch.PersonsEntitled {
+			persons = append(persons, p.Name)
+		}
+		code := ch.ChargeCode
+		if code == "" {
+			code = strconv.Itoa(ch.ChargeNumber)
+		}
+		t.rows = append(t.rows, []string{code, ch.Status.String(), date(ch.CreatedOn), date(ch.SatisfiedOn), strings.Join(persons, "; ")})
+	}
+	return res, t, nil
+}
+
+func pscCmd(a *api.API, number string, opts options) (interface{}, table, error) {
+	c, err := a.GetCompany(number)
+	if err != nil {
+		return nil, table{}, err
+	}
+
+	res, err := c.PersonsWithSignificantControl(paging(opts)...)
+	if err != nil {
+		return nil, table{}, err
+	}
+
+	t := table{header: []string{"name", "kind", "notified", "ceased", "natures of control"}}
+	for _, p := range res.Items {
+		noc := make([]string, len(p.NaturesOfControl))
+		for i, n := range p.NaturesOfControl {
+			noc[i] = string(n)
+		}
+		name := p.Name
+		if name == "" {
+			name = p.Description
+		}
+		kind := strings.TrimSuffix(string(p.Kind), "-person-with-significant-control")
+		t.rows = append(t.rows, []string{name, kind, date(p.NotifiedOn), date(p.CeasedOn), strings.Join(noc, "; ")})
+	}
+	return res, t, nil
+}
+
+func searchCmd(a *api.API, query string, opts options) (interface{}, table, error) {
+	res, err := a.SearchCompanies(query, paging(opts)...)
+	if err != nil {
+		return nil, table{}, err
+	}
+
+	t := table{header: []string{"number", "name", "status", "created", "address"}}
+	for _, r := range res.Items {
+		t.rows = append(t.rows, []string{r.CompanyNumber, r.Title, r.CompanyStatus.String(), date(r.DateOfCreation), r.AddressSnippet})
+	}
+	return res, t, nil
+}
+
+func appointmentsCmd(a *api.API, id string, opts options) (interface{}, table, error) {
+	res, err := a.OfficerAppointments(id, paging(opts)...)
+	if err != nil {
+		return nil, table{}, err
+	}
+
+	t := table{header: []string{"number", "company", "status", "role", "appointed", "resigned"}}
+	for _, ap := range res.Items {
+		t.rows = append(t.rows, []string{ap.AppointedTo.CompanyNumber, ap.AppointedTo.CompanyName, ap.AppointedTo.CompanyStatus.String(), ap.OfficerRole.String(), date(ap.AppointedOn), date(ap.ResignedOn)})
+	}
+	return res, t, nil
+}
+
+func date(d api.ChDate) string {
+	b, _ := d.MarshalText()
+	return string(b)
+}
+
+func address(a api.Address) string {
+	var parts []string
+	for _, p := range []string{a.CareOf, a.PoBox, a.Premises, a.AddressLine1, a.AddressLine2, a.Locality, a.Region, a.PostalCode, a.Country} {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/appinesshq/globire-go/uk/ch/api"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// config contains the settings read from the config file
+type config struct {
+	Key string `yaml:"key"`
+	Env string `yaml:"env"`
+	URL string `yaml:"url"`
+}
+
+// defaultConfigPath returns the path of the config file in the user's config directory
+func defaultConfigPath() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "chq", "config.yaml")
+}
+
+// loadConfig reads the config file. A missing file is only an error if the path was provided explicitly.
+func loadConfig(path string) (config, error) {
+	var cfg config
+	explicit := path != ""
+	if !explicit {
+		path = defaultConfigPath()
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && !explicit {
+			return cfg, nil
+		}
+		return cfg, errors.Wrap(err, "reading config")
+	}
+
+	if err := yaml.Unmarshal(b, &cfg); err != nil {
+		return cfg, errors.Wrap(err, "decoding config")
+	}
+	return cfg, nil
+}
+
+// newAPI returns an API using the key, environment and URL from the flags, the environment or the config file.
+// A URL overrides the environment.
+func newAPI(opts options) (*api.API, error) {
+	cfg, err := loadConfig(opts.config)
+	if err != nil {
+		return nil, err
+	}
+
+	key := opts.key
+	if key == "" {
+		key = os.Getenv("CH_API_KEY")
+	}
+	if key == "" {
+		key = cfg.Key
+	}
+
+	env := api.Live
+	name := opts.env
+	if name == "" {
+		name = cfg.Env
+	}
+	if name != "" {
+		var ok bool
+		if env, ok = api.EnvironmentByName(name); !ok {
+			return nil, errors.Errorf("unknown environment %q", name)
+		}
+	}
+
+	u := opts.url
+	if u == "" {
+		u = cfg.URL
+	}
+	if u != "" {
+		env = api.CustomEnvironment(u)
+	}
+
+	a, err := api.New(key, api.WithEnvironment(env))
+	if err != nil {
+		return nil, errors.Wrap(err, "set the API key with -key, CH_API_KEY or the config file")
+	}
+
+	return a, nil
+}
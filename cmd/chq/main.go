@@ -0,0 +1,119 @@
+// Command chq queries the Companies House REST API from the command line.
+//
+// Usage:
+//
+//	chq [flags] <command> <argument>
+//
+// Commands:
+//
+//	company <company number>        company profile
+//	officers <company number>       officers of a company
+//	filings <company number>        filing history of a company
+//	charges <company number>        charges of a company
+//	psc <company number>            persons with significant control of a company
+//	search <query>                  search for companies
+//	appointments <officer id>       appointments of an officer
+//
+// The API key is read from the -key flag, the CH_API_KEY environment variable or the
+// config file, in that order. The config file is YAML with the fields key, env and url, and
+// defaults to $XDG_CONFIG_HOME/chq/config.yaml.
+//
+// Results are printed as a table, unless one of the -json, -yaml or -csv flags is set.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+type options struct {
+	key      string
+	config   string
+	env      string
+	url      string
+	format   format
+	limit    int
+	start    int
+	category string
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "chq: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, w io.Writer) error {
+	var opts options
+	var asJSON, asYAML, asCSV bool
+
+	fs := flag.NewFlagSet("chq", flag.ContinueOnError)
+	fs.StringVar(&opts.key, "key", "", "Companies House API key")
+	fs.StringVar(&opts.config, "config", "", "path of the config file")
+	fs.StringVar(&opts.env, "env", "", "environment of the API: live, sandbox or legacy")
+	fs.StringVar(&opts.url, "url", "", "base URL of the API, which overrides -env")
+	fs.BoolVar(&asJSON, "json", false, "print the result as JSON")
+	fs.BoolVar(&asYAML, "yaml", false, "print the result as YAML")
+	fs.BoolVar(&asCSV, "csv", false, "print the result as CSV")
+	fs.IntVar(&opts.limit, "limit", 0, "maximum number of items to return")
+	fs.IntVar(&opts.start, "start", 0, "index of the first item to return")
+	fs.StringVar(&opts.category, "category", "", "comma separated filing categories to filter on")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: chq [flags] <command> <argument>\n\nCommands:\n")
+		for _, c := range commands {
+			fmt.Fprintf(fs.Output(), "  %-14s %s\n", c.name+" <"+c.arg+">", c.help)
+		}
+		fmt.Fprintf(fs.Output(), "\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	// Flags are allowed before and after the command and its argument
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	switch {
+	case asJSON:
+		opts.format = formatJSON
+	case asYAML:
+		opts.format = formatYAML
+	case asCSV:
+		opts.format = formatCSV
+	}
+
+	if len(positional) < 2 {
+		fs.Usage()
+		return fmt.Errorf("missing command or argument")
+	}
+
+	name, arg := positional[0], strings.Join(positional[1:], " ")
+	for _, c := range commands {
+		if c.name == name {
+			a, err := newAPI(opts)
+			if err != nil {
+				return err
+			}
+
+			v, t, err := c.run(a, arg, opts)
+			if err != nil {
+				return err
+			}
+			return write(w, opts.format, v, t)
+		}
+	}
+
+	fs.Usage()
+	return fmt.Errorf("unknown command %q", name)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/appinesshq/globire-go/uk/ch/api/tests"
+)
+
+func TestRun(t *testing.T) {
+	ts := tests.NewMockServer()
+	defer ts.Close()
+
+	tt := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"company", "12345678"}, "TEST LTD"},
+		{[]string{"officers", "12345678"}, "PERSON, Test"},
+		{[]string{"filings", "12345678", "-category", "incorporation"}, "Incorporation"},
+		{[]string{"charges", "12345678"}, "Test Bank PLC"},
+		{[]string{"psc", "12345678"}, "PARENT LTD"},
+		{[]string{"search", "test", "ltd"}, "87654321"},
+		{[]string{"appointments", "e4-ScyHpxNNUh6ZyV9wnqZS1kfY"}, "PARENT LTD"},
+		{[]string{"-yaml", "company", "12345678"}, "company_name: TEST LTD"},
+	}
+
+	for _, tc := range tt {
+		var buf bytes.Buffer
+		args := append([]string{"-key", "12345", "-config", "/dev/null", "-url", ts.URL}, tc.args...)
+		if err := run(args, &buf); err != nil {
+			t.Fatalf("%v: expected to pass, but got: %v", tc.args, err)
+		}
+
+		if !strings.Contains(buf.String(), tc.expected) {
+			t.Errorf("%v: expected output to contain %q, but got:\n%s", tc.args, tc.expected, buf.String())
+		}
+	}
+}
+
+func TestRunFormats(t *testing.T) {
+	ts := tests.NewMockServer()
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	if err := run([]string{"officers", "12345678", "-json", "-key", "12345", "-config", "/dev/null", "-url", ts.URL}, &buf); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	var v map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &v); err != nil {
+		t.Fatalf("expected valid JSON, but got: %v", err)
+	}
+	if got, expected := v["kind"], "officer-list"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	buf.Reset()
+	if err := run([]string{"-csv", "-key", "12345", "-config", "/dev/null", "-url", ts.URL, "officers", "12345678"}, &buf); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("expected valid CSV, but got: %v", err)
+	}
+	if got, expected := rows[1][0], "PERSON, Test"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	var buf bytes.Buffer
+	if err := run([]string{"-config", "/dev/null", "unknown", "1"}, &buf); err == nil {
+		t.Errorf("expected an error for an unknown command")
+	}
+
+	if err := run([]string{"-config", "/dev/null", "company"}, &buf); err == nil {
+		t.Errorf("expected an error for a missing argument")
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// format represents an output format
+type format int
+
+const (
+	formatTable format = iota
+	formatJSON
+	formatYAML
+	formatCSV
+)
+
+// table contains the tabular representation of a result
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+// write prints the result in the requested format. JSON and YAML contain the full API response,
+// tables and CSV only the columns of the table.
+func write(w io.Writer, f format, v interface{}, t table) error {
+	switch f {
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+
+	case formatYAML:
+		// Convert through JSON, so the YAML uses the same field names as the API
+		b, err := json.Marshal(v)
+		if err != nil {
+			return errors.Wrap(err, "encoding result")
+		}
+		var m interface{}
+		if err := json.Unmarshal(b, &m); err != nil {
+			return errors.Wrap(err, "encoding result")
+		}
+		out, err := yaml.Marshal(m)
+		if err != nil {
+			return errors.Wrap(err, "encoding result")
+		}
+		_, err = w.Write(out)
+		return err
+
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(t.header); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(t.rows); err != nil {
+			return err
+		}
+		return cw.Error()
+
+	default:
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.ToUpper(strings.Join(t.header, "\t")))
+		for _, r := range t.rows {
+			fmt.Fprintln(tw, strings.Join(r, "\t"))
+		}
+		return tw.Flush()
+	}
+}
@@ -0,0 +1,127 @@
+// Command enumgen regenerates the built-in tables of the enum package from a checkout of the
+// upstream api-enumerations repository (https://github.com/companieshouse/api-enumerations).
+//
+// Usage:
+//
+//	enumgen -src <api-enumerations checkout> [-dst <enum package directory>]
+//
+// Tables which don't exist in the source directory are left unchanged. The comments before the
+// package clause of an existing file, e.g. a license header, are kept.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/appinesshq/globire-go/uk/ch/api/enum"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// tables maps the upstream file names to the Go files and constants of the enum package
+var tables = []struct {
+	yaml, file, constant string
+}{
+	{"constants.yml", "constants.go", "constantsYAML"},
+	{"filing_history_descriptions.yml", "filing_history_descriptions.go", "filingHistoryDescriptionsYAML"},
+	{"mortgage_descriptions.yml", "mortgage_descriptions.go", "mortgageDescriptionsYAML"},
+	{"disqualified_officer_descriptions.yml", "disqualified_officer_descriptions.go", "disqualifiedOfficerDescriptionsYAML"},
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "enumgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("enumgen", flag.ContinueOnError)
+	src := fs.String("src", "", "directory of the api-enumerations checkout")
+	dst := fs.String("dst", ".", "directory of the enum package")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *src == "" {
+		return errors.New("missing -src")
+	}
+
+	for _, t := range tables {
+		b, err := ioutil.ReadFile(filepath.Join(*src, t.yaml))
+		if os.IsNotExist(err) {
+			fmt.Fprintf(w, "skipped %s\n", t.yaml)
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "reading %s", t.yaml)
+		}
+
+		// Fail before replacing a table with one which can't be loaded
+		var e enum.ENUM
+		if err := yaml.Unmarshal(b, &e); err != nil {
+			return errors.Wrapf(err, "decoding %s", t.yaml)
+		}
+
+		path := filepath.Join(*dst, t.file)
+		h, err := header(path)
+		if err != nil {
+			return errors.Wrapf(err, "reading %s", t.file)
+		}
+
+		code, err := generate(h, t.yaml, t.constant, b)
+		if err != nil {
+			return errors.Wrapf(err, "generating %s", t.file)
+		}
+		if err := ioutil.WriteFile(path, code, 0644); err != nil {
+			return errors.Wrapf(err, "writing %s", t.file)
+		}
+		fmt.Fprintf(w, "generated %s from %s (%d sections)\n", t.file, t.yaml, len(e))
+	}
+
+	return nil
+}
+
+// header returns the text before the package clause of an existing file, without the generated
+// code comment. It returns an empty string if the file doesn't exist.
+func header(path string) (string, error) {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var h strings.Builder
+	for _, line := range strings.SplitAfter(string(b), "\n") {
+		if strings.HasPrefix(line, "package ") {
+			break
+		}
+		if !strings.HasPrefix(line, "// Code generated ") {
+			h.WriteString(line)
+		}
+	}
+	return strings.TrimSpace(h.String()), nil
+}
+
+// generate returns the Go source of a file with the YAML as a string constant, after the header
+func generate(header, name, constant string, b []byte) ([]byte, error) {
+	// Backquotes can't be part of a raw string literal
+	s := strings.ReplaceAll(string(b), "`", "` + \"`\" + `")
+
+	var src strings.Builder
+	if header != "" {
+		fmt.Fprintf(&src, "%s\n\n", header)
+	}
+	fmt.Fprintf(&src, "// Code generated by enumgen from %s; DO NOT EDIT.\n\n", name)
+	fmt.Fprintf(&src, "package enum\n\n")
+	fmt.Fprintf(&src, "const %s string = `%s`\n", constant, s)
+
+	return format.Source([]byte(src.String()))
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	src, err := ioutil.TempDir("", "enumgen-src")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	defer os.RemoveAll(src)
+
+	dst, err := ioutil.TempDir("", "enumgen-dst")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	defer os.RemoveAll(dst)
+
+	yml := "---\ncompany_status:\n    'active' : \"Active `now`\"\n"
+	if err := ioutil.WriteFile(filepath.Join(src, "constants.yml"), []byte(yml), 0644); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := run([]string{"-src", src, "-dst", dst}, &out); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dst, "constants.go"))
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if got, expected := string(b), "const constantsYAML string = `---\ncompany_status:\n    'active' : \"Active ` + \"`\" + `now` + \"`\" + `\"\n`"; !strings.Contains(got, expected) {
+		t.Errorf("expected %q in %q", expected, got)
+	}
+
+	if _, err := os.Stat(filepath.Join(dst, "mortgage_descriptions.go")); !os.IsNotExist(err) {
+		t.Errorf("expected missing tables to be skipped")
+	}
+
+	if !strings.Contains(out.String(), "skipped mortgage_descriptions.yml") {
+		t.Errorf("expected skipped tables to be reported, but got %q", out.String())
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(src, "constants.yml"), []byte("company_status: ["), 0644); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if err := run([]string{"-src", src, "-dst", dst}, &out); err == nil {
+		t.Errorf("expected invalid YAML to fail")
+	}
+}
+
+func TestRunHeader(t *testing.T) {
+	src, err := ioutil.TempDir("", "enumgen-src")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	defer os.RemoveAll(src)
+
+	dst, err := ioutil.TempDir("", "enumgen-dst")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	defer os.RemoveAll(dst)
+
+	if err := ioutil.WriteFile(filepath.Join(src, "mortgage_descriptions.yml"), []byte("---\nstatus:\n    'satisfied' : \"Satisfied\"\n"), 0644); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	license := "/*Package enum is a part of the library\n\nLicensed under the Apache License, Version 2.0 (the \"License\");\n*/"
+	existing := license + "\n\npackage enum\n\nconst mortgageDescriptionsYAML string = `---`\n"
+	if err := ioutil.WriteFile(filepath.Join(dst, "mortgage_descriptions.go"), []byte(existing), 0644); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	// Generating twice keeps a single header
+	for i := 0; i < 2; i++ {
+		if err := run([]string{"-src", src, "-dst", dst}, ioutil.Discard); err != nil {
+			t.Fatalf("expected to pass, but got: %v", err)
+		}
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dst, "mortgage_descriptions.go"))
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	expected := license + "\n\n// Code generated by enumgen from mortgage_descriptions.yml; DO NOT EDIT.\n\npackage enum\n"
+	if got := string(b); !strings.HasPrefix(got, expected) {
+		t.Errorf("expected %q to start with %q", got, expected)
+	}
+	if got := strings.Count(string(b), "Code generated"); got != 1 {
+		t.Errorf("expected a single generated code comment, but got %d", got)
+	}
+}
@@ -1,6 +1,6 @@
 module github.com/appinesshq/globire-go
 
-go 1.15
+go 1.16
 
 require (
 	github.com/pkg/errors v0.9.1
@@ -0,0 +1,144 @@
+package accounts_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/appinesshq/globire-go/uk/ch/api/accounts"
+)
+
+const ixbrl = `<?xml version="1.0" encoding="UTF-8"?>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:ix="http://www.xbrl.org/2013/inlineXBRL"
+	xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:xbrldi="http://xbrl.org/2006/xbrldi"
+	xmlns:iso4217="http://www.xbrl.org/2003/iso4217" xmlns:ixt2="http://www.xbrl.org/inlineXBRL/transformation/2011-07-31"
+	xmlns:core="http://xbrl.frc.org.uk/fr/2014-09-01/core" xmlns:bus="http://xbrl.frc.org.uk/cd/2014-09-01/business">
+<head><title>TEST LTD accounts</title></head>
+<body>
+<div style="display:none">
+<ix:header><ix:resources>
+	<xbrli:context id="FY2020"><xbrli:entity><xbrli:identifier scheme="http://www.companieshouse.gov.uk/">12345678</xbrli:identifier></xbrli:entity>
+		<xbrli:period><xbrli:startDate>2019-07-01</xbrli:startDate><xbrli:endDate>2020-06-30</xbrli:endDate></xbrli:period></xbrli:context>
+	<xbrli:context id="BS2020"><xbrli:entity><xbrli:identifier scheme="http://www.companieshouse.gov.uk/">12345678</xbrli:identifier></xbrli:entity>
+		<xbrli:period><xbrli:instant>2020-06-30</xbrli:instant></xbrli:period></xbrli:context>
+	<xbrli:context id="BS2019"><xbrli:entity><xbrli:identifier scheme="http://www.companieshouse.gov.uk/">12345678</xbrli:identifier></xbrli:entity>
+		<xbrli:period><xbrli:instant>2019-06-30</xbrli:instant></xbrli:period></xbrli:context>
+	<xbrli:context id="BS2020_within1"><xbrli:entity><xbrli:identifier scheme="http://www.companieshouse.gov.uk/">12345678</xbrli:identifier>
+		<xbrli:segment><xbrldi:explicitMember dimension="core:MaturitiesOrExpirationPeriodsDimension">core:WithinOneYear</xbrldi:explicitMember></xbrli:segment></xbrli:entity>
+		<xbrli:period><xbrli:instant>2020-06-30</xbrli:instant></xbrli:period></xbrli:context>
+	<xbrli:context id="FY2020_std"><xbrli:entity><xbrli:identifier scheme="http://www.companieshouse.gov.uk/">12345678</xbrli:identifier>
+		<xbrli:segment><xbrldi:explicitMember dimension="bus:AccountingStandardsDimension">bus:Micro-entities</xbrldi:explicitMember></xbrli:segment></xbrli:entity>
+		<xbrli:period><xbrli:startDate>2019-07-01</xbrli:startDate><xbrli:endDate>2020-06-30</xbrli:endDate></xbrli:period></xbrli:context>
+	<xbrli:unit id="GBP"><xbrli:measure>iso4217:GBP</xbrli:measure></xbrli:unit>
+	<xbrli:unit id="pure"><xbrli:measure>xbrli:pure</xbrli:measure></xbrli:unit>
+	<ix:nonNumeric name="bus:AccountsTypeFullOrAbbreviated" contextRef="FY2020_std">Full</ix:nonNumeric>
+</ix:resources></ix:header>
+</div>
+<p>Registered number: <ix:nonNumeric name="bus:UKCompaniesHouseRegisteredNumber" contextRef="FY2020">12345678</ix:nonNumeric></p>
+<p><ix:nonNumeric name="bus:EntityCurrentLegalOrRegisteredName" contextRef="FY2020">TEST LTD</ix:nonNumeric></p>
+<p>Balance sheet as at <ix:nonNumeric name="bus:BalanceSheetDate" contextRef="BS2020" format="ixt2:datelongukformat">30th June 2020</ix:nonNumeric></p>
+<table>
+<tr><td>Turnover</td><td>£<ix:nonFraction name="core:TurnoverRevenue" contextRef="FY2020" unitRef="GBP" decimals="-3" scale="3" format="ixt2:numdotdecimal">1,234</ix:nonFraction>k</td></tr>
+<tr><td>Cash</td><td><ix:nonFraction name="core:CashBankOnHand" contextRef="BS2020" unitRef="GBP" decimals="0">45,678</ix:nonFraction></td>
+	<td><ix:nonFraction name="core:CashBankOnHand" contextRef="BS2019" unitRef="GBP" decimals="0">1,000</ix:nonFraction></td></tr>
+<tr><td>Creditors</td><td>(<ix:nonFraction name="core:Creditors" contextRef="BS2020_within1" unitRef="GBP" decimals="0">12<span>,</span>000</ix:nonFraction>)</td></tr>
+<tr><td>Net assets</td><td>(<ix:nonFraction name="core:NetAssetsLiabilities" contextRef="BS2020" unitRef="GBP" decimals="0" sign="-">2,500</ix:nonFraction>)</td></tr>
+<tr><td>Equity</td><td><ix:nonFraction name="core:Equity" contextRef="BS2020" unitRef="GBP" decimals="0" format="ixt2:fixed-zero">-</ix:nonFraction></td></tr>
+<tr><td>Employees</td><td><ix:nonFraction name="core:AverageNumberEmployeesDuringPeriod" contextRef="FY2020" unitRef="pure" decimals="0">3</ix:nonFraction></td></tr>
+</table>
+<br>
+</body>
+</html>`
+
+const xbrl = `<?xml version="1.0" encoding="UTF-8"?>
+<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:uk-gaap="http://www.xbrl.org/uk/gaap/core/2009-09-01"
+	xmlns:uk-bus="http://www.xbrl.org/uk/cd/business/2009-09-01" xmlns:iso4217="http://www.xbrl.org/2003/iso4217">
+	<xbrli:context id="y2014"><xbrli:entity><xbrli:identifier scheme="http://www.companieshouse.gov.uk/">00000001</xbrli:identifier></xbrli:entity>
+		<xbrli:period><xbrli:startDate>2013-04-01</xbrli:startDate><xbrli:endDate>2014-03-31</xbrli:endDate></xbrli:period></xbrli:context>
+	<xbrli:context id="e2014"><xbrli:entity><xbrli:identifier scheme="http://www.companieshouse.gov.uk/">00000001</xbrli:identifier></xbrli:entity>
+		<xbrli:period><xbrli:instant>2014-03-31</xbrli:instant></xbrli:period></xbrli:context>
+	<xbrli:unit id="GBP"><xbrli:measure>iso4217:GBP</xbrli:measure></xbrli:unit>
+	<uk-bus:UKCompaniesHouseRegisteredNumber contextRef="y2014">00000001</uk-bus:UKCompaniesHouseRegisteredNumber>
+	<uk-gaap:TurnoverGrossOperatingRevenue contextRef="y2014" unitRef="GBP" decimals="0">500000</uk-gaap:TurnoverGrossOperatingRevenue>
+	<uk-gaap:CreditorsDueWithinOneYear contextRef="e2014" unitRef="GBP" decimals="0">20000</uk-gaap:CreditorsDueWithinOneYear>
+	<uk-gaap:ShareholderFunds contextRef="e2014" unitRef="GBP" decimals="0">-1500</uk-gaap:ShareholderFunds>
+</xbrli:xbrl>`
+
+func TestParseIXBRL(t *testing.T) {
+	s, err := accounts.ParseSummary(strings.NewReader(ixbrl))
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	if got, expected := s.CompanyNumber, "12345678"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if got, expected := s.Taxonomy, accounts.FRC; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if got, expected := s.Standard, "FRS 105"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if got, expected := s.BalanceSheetDate.Format("2006-01-02"), "2020-06-30"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if got, expected := s.Currency, "GBP"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	values := []struct {
+		name     string
+		got      *float64
+		expected float64
+	}{
+		{"turnover", s.Turnover, 1234000},
+		{"cash", s.Cash, 45678},
+		{"current liabilities", s.CurrentLiabilities, 12000},
+		{"net assets", s.NetAssets, -2500},
+		{"equity", s.Equity, 0},
+		{"employees", s.Employees, 3},
+	}
+	for _, v := range values {
+		if v.got == nil {
+			t.Errorf("expected %s to be set", v.name)
+			continue
+		}
+		if *v.got != v.expected {
+			t.Errorf("expected %s %v, but got %v", v.name, v.expected, *v.got)
+		}
+	}
+
+	if s.ProfitLoss != nil {
+		t.Errorf("expected untagged profit to be nil, but got %v", *s.ProfitLoss)
+	}
+}
+
+func TestParseXBRL(t *testing.T) {
+	s, err := accounts.ParseSummary(strings.NewReader(xbrl))
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	if got, expected := s.Taxonomy, accounts.UKGAAP; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if got, expected := s.BalanceSheetDate.Format("2006-01-02"), "2014-03-31"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if s.Turnover == nil || *s.Turnover != 500000 {
+		t.Errorf("expected turnover 500000, but got %v", s.Turnover)
+	}
+
+	if s.CurrentLiabilities == nil || *s.CurrentLiabilities != 20000 {
+		t.Errorf("expected current liabilities 20000, but got %v", s.CurrentLiabilities)
+	}
+
+	if s.Equity == nil || *s.Equity != -1500 {
+		t.Errorf("expected equity -1500, but got %v", s.Equity)
+	}
+}
@@ -0,0 +1,322 @@
+// Package accounts parses company accounts filed in the iXBRL or XBRL format, from the bulk
+// accounts products or the Document API, into a typed summary of the key financials.
+package accounts
+
+import (
+	"encoding/xml"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	nsInlineXBRL = "http://www.xbrl.org/2013/inlineXBRL"
+	nsXBRLI      = "http://www.xbrl.org/2003/instance"
+	nsXBRLDI     = "http://xbrl.org/2006/xbrldi"
+)
+
+// Context represents the period and dimensions a fact applies to
+type Context struct {
+	ID         string
+	Instant    time.Time
+	Start      time.Time
+	End        time.Time
+	Dimensions map[string]string // Local name of the dimension -> local name of the member
+}
+
+// Date returns the instant of the context, or the end of its period
+func (c *Context) Date() time.Time {
+	if !c.Instant.IsZero() {
+		return c.Instant
+	}
+	return c.End
+}
+
+// Fact represents a single tagged value in the accounts
+type Fact struct {
+	Name      string // Local name of the concept, e.g. TurnoverRevenue
+	Namespace string
+	ContextID string
+	Context   *Context
+	Unit      string
+	Decimals  string
+	Text      string  // The value as it appears in the document
+	Value     float64 // The numeric value, including scale and sign
+	Numeric   bool
+}
+
+// Document contains all facts and contexts of an iXBRL or XBRL document
+type Document struct {
+	Facts    []Fact
+	Contexts map[string]*Context
+	Units    map[string]string // Unit ID -> measure, e.g. iso4217:GBP
+}
+
+// Parse reads an iXBRL (XHTML) or XBRL (XML) document
+func Parse(r io.Reader) (*Document, error) {
+	doc := &Document{Contexts: make(map[string]*Context), Units: make(map[string]string)}
+
+	dec := xml.NewDecoder(r)
+	dec.Strict = false
+	dec.AutoClose = xml.HTMLAutoClose
+	dec.Entity = xml.HTMLEntity
+
+	p := parser{doc: doc, prefixes: make(map[string]string)}
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing document")
+		}
+		p.token(tok)
+	}
+
+	for i := range doc.Facts {
+		f := &doc.Facts[i]
+		f.Context = doc.Contexts[f.ContextID]
+		if u, ok := doc.Units[f.Unit]; ok {
+			f.Unit = u
+		}
+	}
+
+	return doc, nil
+}
+
+// parser keeps the state while walking through the tokens of a document
+type parser struct {
+	doc     *Document
+	depth   int
+	root    string // Namespace of the root element, to detect plain XBRL instances
+	context *Context
+	dim     string
+	unit    string
+	field   string // Element of a context or unit whose text is being read
+	text    strings.Builder
+	facts   []*openFact // Facts which haven't been closed yet; iXBRL facts can be nested
+
+	prefixes map[string]string // Namespace declarations, to resolve the prefixes in attribute values
+}
+
+type openFact struct {
+	fact    Fact
+	depth   int
+	format  string
+	scale   int
+	sign    bool
+	exclude int // Depth of an ix:exclude element, whose text isn't part of the value
+}
+
+func attr(se xml.StartElement, name string) string {
+	for _, a := range se.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+func (p *parser) token(tok xml.Token) {
+	switch t := tok.(type) {
+	case xml.StartElement:
+		p.depth++
+		p.start(t)
+	case xml.EndElement:
+		p.end(t)
+		p.depth--
+	case xml.CharData:
+		for _, f := range p.facts {
+			if f.exclude == 0 {
+				f.fact.Text += string(t)
+			}
+		}
+		if p.field != "" {
+			p.text.Write(t)
+		}
+	}
+}
+
+func (p *parser) start(se xml.StartElement) {
+	if p.depth == 1 {
+		p.root = se.Name.Space
+	}
+	for _, a := range se.Attr {
+		if a.Name.Space == "xmlns" {
+			p.prefixes[a.Name.Local] = a.Value
+		}
+	}
+
+	for _, f := range p.facts {
+		if se.Name.Space == nsInlineXBRL && se.Name.Local == "exclude" && f.exclude == 0 {
+			f.exclude = p.depth
+		}
+	}
+
+	switch {
+	case se.Name.Space == nsXBRLI && se.Name.Local == "context":
+		p.context = &Context{ID: attr(se, "id"), Dimensions: make(map[string]string)}
+	case se.Name.Space == nsXBRLI && se.Name.Local == "unit":
+		p.unit = attr(se, "id")
+	case p.context != nil && se.Name.Space == nsXBRLI && (se.Name.Local == "instant" || se.Name.Local == "startDate" || se.Name.Local == "endDate"):
+		p.field = se.Name.Local
+		p.text.Reset()
+	case p.context != nil && se.Name.Space == nsXBRLDI && se.Name.Local == "explicitMember":
+		p.dim = localName(attr(se, "dimension"))
+		p.field = "member"
+		p.text.Reset()
+	case p.unit != "" && se.Name.Space == nsXBRLI && se.Name.Local == "measure":
+		p.field = "measure"
+		p.text.Reset()
+
+	case se.Name.Space == nsInlineXBRL && (se.Name.Local == "nonFraction" || se.Name.Local == "nonNumeric"):
+		name := attr(se, "name")
+		f := &openFact{depth: p.depth, format: attr(se, "format"), sign: attr(se, "sign") == "-"}
+		f.scale, _ = strconv.Atoi(attr(se, "scale"))
+		f.fact = Fact{
+			Name:      localName(name),
+			Namespace: p.namespace(name),
+			ContextID: attr(se, "contextRef"),
+			Unit:      attr(se, "unitRef"),
+			Decimals:  attr(se, "decimals"),
+			Numeric:   se.Name.Local == "nonFraction",
+		}
+		p.facts = append(p.facts, f)
+
+	case p.root == nsXBRLI && p.depth == 2 && attr(se, "contextRef") != "":
+		// Facts of a plain XBRL instance are the children of the root element
+		f := &openFact{depth: p.depth}
+		f.fact = Fact{
+			Name:      se.Name.Local,
+			Namespace: se.Name.Space,
+			ContextID: attr(se, "contextRef"),
+			Unit:      attr(se, "unitRef"),
+			Decimals:  attr(se, "decimals"),
+			Numeric:   attr(se, "unitRef") != "",
+		}
+		p.facts = append(p.facts, f)
+	}
+}
+
+func (p *parser) end(ee xml.EndElement) {
+	for _, f := range p.facts {
+		if f.exclude == p.depth {
+			f.exclude = 0
+		}
+	}
+
+	if n := len(p.facts); n > 0 && p.facts[n-1].depth == p.depth {
+		f := p.facts[n-1]
+		p.facts = p.facts[:n-1]
+		f.fact.Text = strings.Join(strings.Fields(f.fact.Text), " ")
+		if f.fact.Numeric {
+			f.fact.Value, f.fact.Numeric = parseNumber(f.fact.Text, f.format, f.scale, f.sign)
+		}
+		p.doc.Facts = append(p.doc.Facts, f.fact)
+		return
+	}
+
+	if p.field != "" {
+		s := strings.TrimSpace(p.text.String())
+		switch p.field {
+		case "instant":
+			p.context.Instant = parseDate(s)
+		case "startDate":
+			p.context.Start = parseDate(s)
+		case "endDate":
+			p.context.End = parseDate(s)
+		case "member":
+			p.context.Dimensions[p.dim] = localName(s)
+		case "measure":
+			p.doc.Units[p.unit] = s
+		}
+		p.field = ""
+		return
+	}
+
+	switch {
+	case ee.Name.Space == nsXBRLI && ee.Name.Local == "context" && p.context != nil:
+		p.doc.Contexts[p.context.ID] = p.context
+		p.context = nil
+	case ee.Name.Space == nsXBRLI && ee.Name.Local == "unit":
+		p.unit = ""
+	}
+}
+
+// parseNumber parses the displayed value of an iXBRL number, using the transformation format
+func parseNumber(s, format string, scale int, negative bool) (float64, bool) {
+	format = localName(format)
+	s = strings.TrimSpace(s)
+
+	switch {
+	case format == "fixed-zero" || format == "zerodash" || format == "numdash" || s == "-" || s == "—" || s == "–":
+		return 0, true
+	case s == "" && format != "nocontent":
+		return 0, false
+	}
+
+	// Plain XBRL values are xsd:decimal, which carry their own sign
+	if format == "" && strings.HasPrefix(s, "-") {
+		negative = !negative
+	}
+
+	// Keep digits and the decimal separator of the format only
+	decimal := '.'
+	if strings.Contains(format, "commadecimal") || format == "num-comma-decimal" {
+		decimal = ','
+	}
+
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == decimal:
+			b.WriteRune('.')
+		case r == '(' || r == ')':
+			// Brackets are presentation only; the sign attribute defines negative values
+		}
+	}
+
+	v, err := strconv.ParseFloat(b.String(), 64)
+	if err != nil {
+		return 0, false
+	}
+	v *= math.Pow10(scale)
+	if negative {
+		v = -v
+	}
+	return v, true
+}
+
+func parseDate(s string) time.Time {
+	t, err := time.Parse("2006-01-02", strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// localName strips the prefix of a qualified name like uk-core:TurnoverRevenue
+func localName(s string) string {
+	if i := strings.LastIndex(s, ":"); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
+
+// namespace resolves the prefix of a qualified name, or returns the prefix if it hasn't been declared
+func (p *parser) namespace(qname string) string {
+	i := strings.Index(qname, ":")
+	if i < 0 {
+		return ""
+	}
+	if ns, ok := p.prefixes[qname[:i]]; ok {
+		return ns
+	}
+	return qname[:i]
+}
@@ -0,0 +1,234 @@
+package accounts
+
+import (
+	"strings"
+	"time"
+)
+
+// Taxonomy represents the XBRL taxonomy the accounts are tagged with
+type Taxonomy string
+
+const (
+	// UKGAAP is the taxonomy for accounts under the old UK GAAP, used until 2015
+	UKGAAP Taxonomy = "uk-gaap"
+
+	// FRC is the taxonomy of the Financial Reporting Council for accounts under FRS 101, 102 and 105
+	FRC Taxonomy = "frc"
+
+	// IFRS is the taxonomy for accounts under the International Financial Reporting Standards
+	IFRS Taxonomy = "ifrs"
+
+	// UnknownTaxonomy is used when none of the facts belong to a supported taxonomy
+	UnknownTaxonomy Taxonomy = ""
+)
+
+// Summary contains the key financials of a set of accounts. Values which aren't tagged
+// in the accounts are nil. Monetary values are in Currency.
+type Summary struct {
+	CompanyNumber string
+	CompanyName   string
+	Taxonomy      Taxonomy
+	Standard      string // Accounting standard, e.g. FRS 102 or FRS 105, if the accounts declare it
+
+	BalanceSheetDate time.Time
+	PeriodStart      time.Time
+	PeriodEnd        time.Time
+	Currency         string
+
+	Turnover           *float64
+	ProfitLoss         *float64
+	FixedAssets        *float64
+	CurrentAssets      *float64
+	Cash               *float64
+	CurrentLiabilities *float64 // Creditors falling due within one year
+	NetCurrentAssets   *float64
+	NetAssets          *float64
+	Equity             *float64
+	Employees          *float64 // Average number of employees during the period
+}
+
+// concept maps the alternative names of a concept in the supported taxonomies onto a field of the Summary
+type concept struct {
+	names    []string          // Local names, in order of precedence
+	duration bool              // Reported over the period rather than at the balance sheet date
+	dims     map[string]string // Dimensions the context must have, by local name
+	field    func(s *Summary) **float64
+}
+
+var withinOneYear = map[string]string{"MaturitiesOrExpirationPeriodsDimension": "WithinOneYear"}
+
+var concepts = []concept{
+	{names: []string{"TurnoverRevenue", "TurnoverGrossOperatingRevenue", "Revenue"}, duration: true,
+		field: func(s *Summary) **float64 { return &s.Turnover }},
+	{names: []string{"ProfitLoss", "ProfitLossForPeriod", "ProfitLossOnOrdinaryActivitiesAfterTax"}, duration: true,
+		field: func(s *Summary) **float64 { return &s.ProfitLoss }},
+	{names: []string{"FixedAssets", "TangibleFixedAssets", "NoncurrentAssets"},
+		field: func(s *Summary) **float64 { return &s.FixedAssets }},
+	{names: []string{"CurrentAssets"},
+		field: func(s *Summary) **float64 { return &s.CurrentAssets }},
+	{names: []string{"CashBankOnHand", "CashBankInHand", "CashAndCashEquivalents"},
+		field: func(s *Summary) **float64 { return &s.Cash }},
+	{names: []string{"CreditorsDueWithinOneYear", "CurrentLiabilities"},
+		field: func(s *Summary) **float64 { return &s.CurrentLiabilities }},
+	{names: []string{"Creditors"}, dims: withinOneYear,
+		field: func(s *Summary) **float64 { return &s.CurrentLiabilities }},
+	{names: []string{"NetCurrentAssetsLiabilities"},
+		field: func(s *Summary) **float64 { return &s.NetCurrentAssets }},
+	{names: []string{"NetAssetsLiabilities", "NetAssetsLiabilitiesIncludingPensionAssetLiability", "NetAssetsLiabilitiesExcludingPensionAssetLiability"},
+		field: func(s *Summary) **float64 { return &s.NetAssets }},
+	{names: []string{"Equity", "ShareholderFunds"},
+		field: func(s *Summary) **float64 { return &s.Equity }},
+	{names: []string{"AverageNumberEmployeesDuringPeriod", "EmployeesTotal"}, duration: true,
+		field: func(s *Summary) **float64 { return &s.Employees }},
+}
+
+// standards maps the members of the accounting standards dimension onto the standard
+var standards = map[string]string{
+	"FRS102":         "FRS 102",
+	"SmallEntities":  "FRS 102",
+	"FRS101":         "FRS 101",
+	"FRS105":         "FRS 105",
+	"Micro-entities": "FRS 105",
+	"MicroEntities":  "FRS 105",
+	"IFRS":           "IFRS",
+	"FullIFRS":       "IFRS",
+	"EUIFRS":         "IFRS",
+	"UKGAAP":         "UK GAAP",
+	"FRSSE":          "FRSSE",
+	"OtherStandards": "Other",
+}
+
+// Summary returns the key financials of the document
+func (d *Document) Summary() *Summary {
+	s := Summary{Taxonomy: d.taxonomy()}
+
+	for _, f := range d.Facts {
+		switch f.Name {
+		case "UKCompaniesHouseRegisteredNumber":
+			s.CompanyNumber = strings.TrimSpace(f.Text)
+		case "EntityCurrentLegalOrRegisteredName":
+			s.CompanyName = f.Text
+		case "BalanceSheetDate":
+			s.BalanceSheetDate = parseDisplayDate(f.Text)
+		case "StartDateForPeriodCoveredByReport":
+			s.PeriodStart = parseDisplayDate(f.Text)
+		case "EndDateForPeriodCoveredByReport":
+			s.PeriodEnd = parseDisplayDate(f.Text)
+		case "AccountingStandardsApplied":
+			if st, ok := standards[strings.ReplaceAll(f.Text, " ", "")]; ok {
+				s.Standard = st
+			}
+		}
+
+		if f.Context != nil && s.Standard == "" {
+			if m, ok := f.Context.Dimensions["AccountingStandardsDimension"]; ok {
+				s.Standard = standards[m]
+			}
+		}
+	}
+
+	// Documents without tagged dates use the latest date of the contexts
+	if s.BalanceSheetDate.IsZero() {
+		s.BalanceSheetDate = s.PeriodEnd
+	}
+	if s.BalanceSheetDate.IsZero() {
+		for _, c := range d.Contexts {
+			if c.Date().After(s.BalanceSheetDate) {
+				s.BalanceSheetDate = c.Date()
+			}
+		}
+	}
+	if s.PeriodEnd.IsZero() {
+		s.PeriodEnd = s.BalanceSheetDate
+	}
+
+	for _, c := range concepts {
+		if f := d.find(c, s.BalanceSheetDate, s.PeriodEnd); f != nil && *c.field(&s) == nil {
+			v := f.Value
+			*c.field(&s) = &v
+			if s.Currency == "" && strings.HasPrefix(f.Unit, "iso4217:") {
+				s.Currency = strings.TrimPrefix(f.Unit, "iso4217:")
+			}
+		}
+	}
+
+	return &s
+}
+
+// find returns the fact for a concept at the balance sheet date or for the period, preferring the
+// names in order of precedence
+func (d *Document) find(c concept, balanceSheetDate, periodEnd time.Time) *Fact {
+	date := balanceSheetDate
+	if c.duration {
+		date = periodEnd
+	}
+
+	for _, name := range c.names {
+		for i := range d.Facts {
+			f := &d.Facts[i]
+			if f.Name != name || !f.Numeric || f.Context == nil || !f.Context.Date().Equal(date) {
+				continue
+			}
+			if c.duration != f.Context.Instant.IsZero() {
+				continue
+			}
+			if matchDimensions(f.Context.Dimensions, c.dims) {
+				return f
+			}
+		}
+	}
+	return nil
+}
+
+// matchDimensions returns true if the context has exactly the required dimensions.
+// The accounting standards dimension is ignored, as some filings add it to every context.
+func matchDimensions(have, want map[string]string) bool {
+	n := 0
+	for dim, member := range have {
+		if dim == "AccountingStandardsDimension" {
+			continue
+		}
+		if want[dim] != member {
+			return false
+		}
+		n++
+	}
+	return n == len(want)
+}
+
+func (d *Document) taxonomy() Taxonomy {
+	for _, f := range d.Facts {
+		ns := strings.ToLower(f.Namespace)
+		switch {
+		case strings.Contains(ns, "xbrl.frc.org.uk"):
+			return FRC
+		case strings.Contains(ns, "xbrl.org/uk/gaap"), strings.Contains(ns, "xbrl.org/uk/fr/gaap"), ns == "uk-gaap":
+			return UKGAAP
+		case strings.Contains(ns, "xbrl.ifrs.org"):
+			return IFRS
+		}
+	}
+	return UnknownTaxonomy
+}
+
+// displayDateLayouts are the formats in which dates are displayed in iXBRL documents
+var displayDateLayouts = []string{
+	"2006-01-02", "2 January 2006", "2 Jan 2006", "02/01/2006", "2/1/2006", "02.01.2006", "2.1.2006", "January 2, 2006", "02-01-2006",
+}
+
+// parseDisplayDate parses a date as displayed in the accounts, e.g. "30th June 2020"
+func parseDisplayDate(s string) time.Time {
+	s = strings.Join(strings.Fields(s), " ")
+	for _, suffix := range []string{"st ", "nd ", "rd ", "th "} {
+		if i := strings.Index(s, suffix); i > 0 && s[i-1] >= '0' && s[i-1] <= '9' {
+			s = s[:i] + " " + s[i+len(suffix):]
+		}
+	}
+
+	for _, layout := range displayDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,64 @@
+package accounts
+
+import (
+	"archive/zip"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ParseSummary reads an iXBRL or XBRL document and returns its key financials
+func ParseSummary(r io.Reader) (*Summary, error) {
+	doc, err := Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	return doc.Summary(), nil
+}
+
+// ReadZip calls fn for every iXBRL (.html) and XBRL (.xml) document in a bulk accounts ZIP file,
+// until fn returns an error. Documents which can't be parsed are passed to fn with the error,
+// so a single malformed document doesn't stop the processing of the file.
+func ReadZip(name string, fn func(file string, s *Summary, err error) error) error {
+	zr, err := zip.OpenReader(name)
+	if err != nil {
+		return errors.Wrap(err, "opening zip")
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		ext := strings.ToLower(path.Ext(f.Name))
+		if ext != ".html" && ext != ".xhtml" && ext != ".xml" {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return errors.Wrapf(err, "opening %s", f.Name)
+		}
+
+		s, err := ParseSummary(rc)
+		rc.Close()
+		if s != nil && s.CompanyNumber == "" {
+			s.CompanyNumber = companyNumberFromFileName(f.Name)
+		}
+
+		if err := fn(f.Name, s, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// companyNumberFromFileName returns the company number from a bulk accounts file name,
+// e.g. Prod223_2345_12345678_20200630.html
+func companyNumberFromFileName(name string) string {
+	parts := strings.Split(strings.TrimSuffix(path.Base(name), path.Ext(name)), "_")
+	if len(parts) < 4 {
+		return ""
+	}
+	return parts[len(parts)-2]
+}
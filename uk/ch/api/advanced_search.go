@@ -0,0 +1,151 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxAdvancedSearchResults is the maximum number of results the advanced search returns for a query
+const MaxAdvancedSearchResults = 5000
+
+type (
+	// AdvancedQuery contains the filters of an advanced company search. Empty fields aren't filtered on.
+	AdvancedQuery struct {
+		NameIncludes     string
+		NameExcludes     string
+		Statuses         []CompanyStatus
+		Subtypes         []string
+		Types            []CompanyType
+		DissolvedFrom    time.Time
+		DissolvedTo      time.Time
+		IncorporatedFrom time.Time
+		IncorporatedTo   time.Time
+		Location         string // Matched against the registered office address, e.g. a town or postcode
+		SICCodes         []SICCode
+		Size             int // Number of results per request, at most MaxAdvancedSearchResults
+		StartIndex       int
+	}
+
+	// AdvancedSearchResult contains a single company returned by an advanced search
+	AdvancedSearchResult struct {
+		CompanyName             string              `json:"company_name"`
+		CompanyNumber           string              `json:"company_number"`
+		CompanyStatus           CompanyStatus       `json:"company_status"`
+		CompanyType             CompanyType         `json:"company_type"`
+		CompanySubtype          string              `json:"company_subtype"`
+		DateOfCessation         ChDate              `json:"date_of_cessation,omitzero"`
+		DateOfCreation          ChDate              `json:"date_of_creation,omitzero"`
+		Kind                    string              `json:"kind"`
+		Links                   CompanyProfileLinks `json:"links"`
+		RegisteredOfficeAddress Address             `json:"registered_office_address"`
+		SICCodes                []SICCode           `json:"sic_codes"`
+	}
+
+	// AdvancedSearch contains the server response of an advanced company search
+	AdvancedSearch struct {
+		Etag   string                 `json:"etag"`
+		Hits   int                    `json:"hits"`
+		Items  []AdvancedSearchResult `json:"items"`
+		Kind   string                 `json:"kind"`
+		TopHit AdvancedSearchResult   `json:"top_hit"`
+	}
+)
+
+// Values returns the query as request parameters
+func (q AdvancedQuery) Values() url.Values {
+	params := url.Values{}
+	set := func(key, val string) {
+		if val != "" {
+			params.Set(key, val)
+		}
+	}
+	date := func(key string, t time.Time) {
+		if !t.IsZero() {
+			params.Set(key, t.Format("2006-01-02"))
+		}
+	}
+
+	set("company_name_includes", q.NameIncludes)
+	set("company_name_excludes", q.NameExcludes)
+	set("location", q.Location)
+
+	statuses := make([]string, len(q.Statuses))
+	for i, s := range q.Statuses {
+		statuses[i] = string(s)
+	}
+	set("company_status", strings.Join(statuses, ","))
+
+	set("company_subtype", strings.Join(q.Subtypes, ","))
+
+	types := make([]string, len(q.Types))
+	for i, t := range q.Types {
+		types[i] = string(t)
+	}
+	set("company_type", strings.Join(types, ","))
+
+	codes := make([]string, len(q.SICCodes))
+	for i, c := range q.SICCodes {
+		codes[i] = string(c)
+	}
+	set("sic_codes", strings.Join(codes, ","))
+
+	date("dissolved_from", q.DissolvedFrom)
+	date("dissolved_to", q.DissolvedTo)
+	date("incorporated_from", q.IncorporatedFrom)
+	date("incorporated_to", q.IncorporatedTo)
+
+	if q.Size > 0 {
+		size := q.Size
+		if size > MaxAdvancedSearchResults {
+			size = MaxAdvancedSearchResults
+		}
+		params.Set("size", strconv.Itoa(size))
+	}
+	if q.StartIndex > 0 {
+		params.Set("start_index", strconv.Itoa(q.StartIndex))
+	}
+
+	return params
+}
+
+// AdvancedSearchCompanies returns a page of the companies matching the query
+func (a *API) AdvancedSearchCompanies(ctx context.Context, q AdvancedQuery) (*AdvancedSearch, error) {
+	res := AdvancedSearch{}
+	if err := a.Do(ctx, http.MethodGet, "/advanced-search/companies", q.Values(), nil, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// AdvancedSearchAllCompanies pages through the companies matching the query, from its start index,
+// until all results or MaxAdvancedSearchResults are returned
+func (a *API) AdvancedSearchAllCompanies(ctx context.Context, q AdvancedQuery) ([]AdvancedSearchResult, error) {
+	if q.Size <= 0 || q.Size > MaxAdvancedSearchResults {
+		q.Size = MaxAdvancedSearchResults
+	}
+
+	var items []AdvancedSearchResult
+	for q.StartIndex < MaxAdvancedSearchResults {
+		if remaining := MaxAdvancedSearchResults - q.StartIndex; q.Size > remaining {
+			q.Size = remaining
+		}
+
+		res, err := a.AdvancedSearchCompanies(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, res.Items...)
+		q.StartIndex += len(res.Items)
+		if len(res.Items) < q.Size || q.StartIndex >= res.Hits {
+			break
+		}
+	}
+
+	return items, nil
+}
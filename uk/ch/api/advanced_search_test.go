@@ -0,0 +1,55 @@
+package api_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ch "github.com/appinesshq/globire-go/uk/ch/api"
+	"github.com/appinesshq/globire-go/uk/ch/api/tests"
+)
+
+func TestAdvancedQueryValues(t *testing.T) {
+	q := ch.AdvancedQuery{
+		Statuses:         []ch.CompanyStatus{"active"},
+		Types:            []ch.CompanyType{"ltd", "plc"},
+		Location:         "Leeds",
+		SICCodes:         []ch.SICCode{"62012"},
+		IncorporatedFrom: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		Size:             10000,
+	}
+
+	expected := "company_status=active&company_type=ltd%2Cplc&incorporated_from=2020-01-01&location=Leeds&sic_codes=62012&size=5000"
+	if got := q.Values().Encode(); got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+}
+
+func TestAdvancedSearchCompanies(t *testing.T) {
+	ts := tests.NewMockServer()
+	defer ts.Close()
+
+	api, err := ch.New("12345", ch.WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	res, err := api.AdvancedSearchCompanies(context.Background(), ch.AdvancedQuery{Location: "Test Town"})
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if got, expected := res.Hits, 2; got != expected {
+		t.Errorf("expected %d hits, but got %d", expected, got)
+	}
+	if got, expected := res.Items[0].SICCodes[1], ch.SICCode("62012"); got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	items, err := api.AdvancedSearchAllCompanies(context.Background(), ch.AdvancedQuery{Size: 1})
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if len(items) != 2 || items[1].CompanyNumber != "87654321" {
+		t.Errorf("expected both companies, but got %+v", items)
+	}
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+type (
+	// AlphabeticalSearchResult contains a single company returned by an alphabetical search
+	AlphabeticalSearchResult struct {
+		CompanyName   string              `json:"company_name"`
+		CompanyNumber string              `json:"company_number"`
+		CompanyStatus CompanyStatus       `json:"company_status"`
+		CompanyType   CompanyType         `json:"company_type"`
+		Kind          string              `json:"kind"`
+		Links         CompanyProfileLinks `json:"links"`
+
+		// OrderedAlphaKeyWithID is the position of the company in the index, which is used as the
+		// cursor for SearchAbove and SearchBelow
+		OrderedAlphaKeyWithID string `json:"ordered_alpha_key_with_id"`
+	}
+
+	// AlphabeticalSearch contains the server response of an alphabetical company search
+	AlphabeticalSearch struct {
+		Etag   string                     `json:"etag"`
+		Items  []AlphabeticalSearchResult `json:"items"`
+		Kind   string                     `json:"kind"`
+		TopHit AlphabeticalSearchResult   `json:"top_hit"`
+	}
+)
+
+// First returns the cursor to get the previous page with SearchAbove, or an empty string if there are no results
+func (s *AlphabeticalSearch) First() string {
+	if len(s.Items) == 0 {
+		return ""
+	}
+	return s.Items[0].OrderedAlphaKeyWithID
+}
+
+// Last returns the cursor to get the next page with SearchBelow, or an empty string if there are no results
+func (s *AlphabeticalSearch) Last() string {
+	if len(s.Items) == 0 {
+		return ""
+	}
+	return s.Items[len(s.Items)-1].OrderedAlphaKeyWithID
+}
+
+// AlphabeticalSearchCompanies returns the companies around the name in alphabetical order
+// Possible options: Size, SearchAbove, SearchBelow
+func (a *API) AlphabeticalSearchCompanies(ctx context.Context, query string, options ...Option) (*AlphabeticalSearch, error) {
+	res := AlphabeticalSearch{}
+	params := url.Values{}
+	for _, option := range options {
+		option(&params)
+	}
+	params.Set("q", query)
+
+	if err := a.Do(ctx, http.MethodGet, "/alphabetical-search/companies", params, nil, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
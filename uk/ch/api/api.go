@@ -1,37 +1,53 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/pkg/errors"
 )
 
-const defaultURL = "https://api.companieshouse.gov.uk"
-
 // API is provides all functionality of the Companies House REST API
 type API struct {
-	Key string
-	URL *url.URL
+	Key          string
+	URL          *url.URL
+	DocumentURL  *url.URL      // Base URL of the document API, nil if not available
+	StreamingURL *url.URL      // Base URL of the streaming API, nil if not available
+	TestDataURL  *url.URL      // Base URL of the test data generator, nil if not available
+	Store        Store         // Optional local copy of company data, see Store
+	MaxAge       time.Duration // Maximum age of resources read from a TimedStore, 0 for no limit
+	Interceptors []Interceptor
+	Metrics      Metrics // Optional, receives the cache hits and misses of the Store, see WithMetrics
+
+	fresh bool // Don't read from the Store, see Fresh
 }
 
-// New returns an initialized instance of an API.
-func New(apiKey string) (*API, error) {
+// New returns an initialized instance of an API, using the Live environment unless an option changes it.
+func New(apiKey string, options ...ClientOption) (*API, error) {
 	if apiKey == "" {
 		return nil, fmt.Errorf("empty API key")
 	}
 
-	u, err := url.Parse(defaultURL)
-	if err != nil {
-		return nil, errors.Wrap(err, "parsing URL")
+	a := &API{Key: apiKey}
+	if err := a.SetEnvironment(Live); err != nil {
+		return nil, err
 	}
 
-	return &API{Key: apiKey, URL: u}, nil
+	for _, option := range options {
+		if err := option(a); err != nil {
+			return nil, err
+		}
+	}
+
+	return a, nil
 }
 
 // RequestError represents an error returned by the CH API.
@@ -57,8 +73,9 @@ func IsRequestError(err error) (bool, *RequestError) {
 }
 
 // DoRequest makes a request to the API and returns the raw http resonse.
-// if the API doesn't return statusOK, DoRequest will try to decode the error to a RequestError, or
-// return the status description if decoding fails.
+// if the API doesn't return statusOK, DoRequest returns no response and the error decoded to a RequestError,
+// or the status description if the body isn't a RequestError.
+// The interceptors of the API are called around the request, see Interceptor.
 func (a *API) DoRequest(ctx context.Context, method string, path string, params url.Values, body io.Reader) (*http.Response, error) {
 	u, err := url.Parse(a.URL.String() + path)
 	if err != nil {
@@ -69,15 +86,35 @@ func (a *API) DoRequest(ctx context.Context, method string, path string, params
 		u.RawQuery = params.Encode()
 	}
 
+	return a.request(ctx, method, u, nil, body)
+}
+
+// request makes a request to an absolute URL with the provided headers, with the same error handling as DoRequest.
+func (a *API) request(ctx context.Context, method string, u *url.URL, header http.Header, body io.Reader) (*http.Response, error) {
 	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
 	if err != nil {
 		return nil, errors.Wrap(err, "creating reuqest")
 	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
 
 	if a.Key == "" {
 		return nil, fmt.Errorf("empty API key")
 	}
 	req.SetBasicAuth(a.Key, "")
+	req = withBasePath(req, a.URL)
+
+	for i, ic := range a.Interceptors {
+		if ic.BeforeRequest == nil {
+			continue
+		}
+		r, err := ic.BeforeRequest(req)
+		if err != nil {
+			return nil, a.intercepted(i+1, req, nil, errors.Wrap(err, "before request"))
+		}
+		req = r
+	}
 
 	tr := &http.Transport{
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
@@ -86,15 +123,31 @@ func (a *API) DoRequest(ctx context.Context, method string, path string, params
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, errors.Wrap(err, "http request")
+		return nil, a.intercepted(len(a.Interceptors), req, nil, errors.Wrap(err, "http request"))
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		defer resp.Body.Close()
-		var err RequestError
+		// Keep the body readable for the interceptors
+		b, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+		if err != nil {
+			return nil, a.intercepted(len(a.Interceptors), req, resp, errors.Wrap(err, "reading response"))
+		}
+
+		var reqErr RequestError
+		if err := json.Unmarshal(b, &reqErr); err != nil || len(reqErr.Errors) == 0 {
+			return nil, a.intercepted(len(a.Interceptors), req, resp, fmt.Errorf("%v", resp.Status))
+		}
+		return nil, a.intercepted(len(a.Interceptors), req, resp, &reqErr)
+	}
 
-		if err := json.NewDecoder(resp.Body).Decode(&err); err != nil {
-			return nil, fmt.Errorf("%v", resp.Status)
+	for i := len(a.Interceptors) - 1; i >= 0; i-- {
+		if ic := a.Interceptors[i]; ic.AfterResponse != nil {
+			if err := ic.AfterResponse(req, resp); err != nil {
+				resp.Body.Close()
+				return nil, a.intercepted(i, req, resp, errors.Wrap(err, "after response"))
+			}
 		}
 	}
 
@@ -3,6 +3,8 @@ package api_test
 import (
 	"context"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"testing"
 
@@ -19,11 +21,66 @@ func TestNewAPI(t *testing.T) {
 		t.Errorf("expected key to be %q, but got %q", expected, got)
 	}
 
-	if got, expected := api.URL.String(), "https://api.companieshouse.gov.uk"; got != expected {
+	if got, expected := api.URL.String(), "https://api.company-information.service.gov.uk"; got != expected {
 		t.Errorf("expected URL to be %q, but got %q", expected, got)
 	}
 }
 
+func TestNewAPIEnvironment(t *testing.T) {
+	api, err := ch.New("test", ch.WithEnvironment(ch.Sandbox))
+	if err != nil {
+		t.Fatalf("expected to pass, but got %v", err)
+	}
+
+	if got, expected := api.URL.String(), "https://api-sandbox.company-information.service.gov.uk"; got != expected {
+		t.Errorf("expected URL to be %q, but got %q", expected, got)
+	}
+
+	if api.DocumentURL != nil {
+		t.Errorf("expected no document API in the sandbox, but got %q", api.DocumentURL)
+	}
+
+	if _, err := api.ResolveLink("https://frontend-doc-api.company-information.service.gov.uk/document/abc"); err == nil {
+		t.Errorf("expected document links to fail without a document API")
+	}
+
+	u, err := api.ResolveLink("https://api.company-information.service.gov.uk/company/12345678")
+	if err != nil {
+		t.Fatalf("expected to pass, but got %v", err)
+	}
+	if got, expected := u.String(), "https://api-sandbox.company-information.service.gov.uk/company/12345678"; got != expected {
+		t.Errorf("expected live links to resolve against the sandbox, but got %q", got)
+	}
+
+	api, err = ch.New("test", ch.WithBaseURL("http://localhost:8080/ch/"))
+	if err != nil {
+		t.Fatalf("expected to pass, but got %v", err)
+	}
+
+	tt := []struct {
+		link, expected string
+	}{
+		{"/company/12345678/officers?start_index=35", "http://localhost:8080/ch/company/12345678/officers?start_index=35"},
+		{"https://frontend-doc-api.company-information.service.gov.uk/document/abc", "http://localhost:8080/ch/document/abc"},
+		{"https://api.company-information.service.gov.uk/company/12345678/filing-history/abc", "http://localhost:8080/ch/company/12345678/filing-history/abc"},
+		{"https://api.companieshouse.gov.uk/company/12345678", "http://localhost:8080/ch/company/12345678"},
+		{"https://example.com/other", "https://example.com/other"},
+	}
+	for _, tc := range tt {
+		u, err := api.ResolveLink(tc.link)
+		if err != nil {
+			t.Fatalf("expected to pass, but got %v", err)
+		}
+		if got := u.String(); got != tc.expected {
+			t.Errorf("expected %q, but got %q", tc.expected, got)
+		}
+	}
+
+	if _, err := ch.New("test", ch.WithBaseURL("")); err == nil {
+		t.Errorf("expected an empty URL to fail")
+	}
+}
+
 func TestDoRequest(t *testing.T) {
 	key := os.Getenv("CH_API_TEST_KEY")
 	if key == "" {
@@ -41,3 +98,38 @@ func TestDoRequest(t *testing.T) {
 		t.Fatalf("expected to pass, but got %v", err)
 	}
 }
+
+func TestDoRequestErrors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/json" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors": [{"error": "company-profile-not-found", "type": "ch:service"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Internal error"))
+	}))
+	defer ts.Close()
+
+	api, err := ch.New("test")
+	if err != nil {
+		t.Fatalf("expected to pass, but got %v", err)
+	}
+	api.URL, err = url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("expected to pass, but got %v", err)
+	}
+
+	resp, err := api.DoRequest(context.Background(), http.MethodGet, "/json", nil, nil)
+	if ok, e := ch.IsRequestError(err); !ok || e.Error() != "company-profile-not-found" {
+		t.Errorf("expected a RequestError, but got %v", err)
+	}
+	if resp != nil {
+		t.Errorf("expected no response, but got %v", resp.Status)
+	}
+
+	_, err = api.DoRequest(context.Background(), http.MethodGet, "/text", nil, nil)
+	if err == nil || err.Error() != "500 Internal Server Error" {
+		t.Errorf("expected the status, but got %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type (
+	// Appointment contains the data of a single appointment of an officer
+	Appointment struct {
+		Address         Address `json:"address"`
+		AppointedBefore ChDate  `json:"appointed_before,omitzero"`
+		AppointedOn     ChDate  `json:"appointed_on,omitzero"`
+		AppointedTo     struct {
+			CompanyName   string        `json:"company_name"`
+			CompanyNumber string        `json:"company_number"`
+			CompanyStatus CompanyStatus `json:"company_status"`
+		} `json:"appointed_to"`
+		CountryOfResidence   string           `json:"country_of_residence"`
+		Identification       Identification   `json:"identification"`
+		IsPre1992Appointment bool             `json:"is_pre_1992_appointment"`
+		Links                AppointmentLinks `json:"links"`
+		Name                 string           `json:"name"`
+		NameElements         NameElements     `json:"name_elements"`
+		Nationality          string           `json:"nationality"`
+		Occupation           string           `json:"occupation"`
+		OfficerRole          OfficerRole      `json:"officer_role"`
+		ResignedOn           ChDate           `json:"resigned_on,omitzero"`
+	}
+
+	// Appointments contains the server response of a request for the appointments of an officer
+	Appointments struct {
+		DateOfBirth        OfficerDateOfBirth `json:"date_of_birth"`
+		Etag               string             `json:"etag"`
+		IsCorporateOfficer bool               `json:"is_corporate_officer"`
+		Items              []Appointment      `json:"items"`
+		ItemsPerPage       int                `json:"items_per_page"`
+		Kind               string             `json:"kind"`
+		Links              AppointmentsLinks  `json:"links"`
+		Name               string             `json:"name"`
+		Start              int                `json:"start_index"`
+		TotalResults       int                `json:"total_results"`
+	}
+)
+
+// OfficerAppointments gets and returns all appointments of an officer
+// Possible options: ItemsPerPage, StartIndex
+func (a *API) OfficerAppointments(officerID string, options ...Option) (*Appointments, error) {
+	res := Appointments{}
+	params := url.Values{}
+	for _, option := range options {
+		option(&params)
+	}
+
+	path := fmt.Sprintf("/officers/%s/appointments", officerID)
+	if err := a.Do(context.Background(), http.MethodGet, path, params, nil, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ukRegistries are the names used for the UK register of companies in the place and country of registration
+var ukRegistries = []string{
+	"companies house", "united kingdom", "england", "wales", "scotland", "northern ireland", "great britain",
+}
+
+// UKCompanyNumber returns the company number of a corporate officer or PSC if it's registered
+// at Companies House, padded to the 8 characters used by the API
+func (id Identification) UKCompanyNumber() (string, bool) {
+	number := strings.ToUpper(strings.Join(strings.Fields(id.RegistrationNumber), ""))
+	if number == "" {
+		return "", false
+	}
+
+	place := strings.ToLower(id.PlaceRegistered + " " + id.CountryRegistered)
+	uk := false
+	for _, r := range ukRegistries {
+		if strings.Contains(place, r) {
+			uk = true
+			break
+		}
+	}
+	for _, w := range strings.FieldsFunc(place, func(r rune) bool { return r < 'a' || r > 'z' }) {
+		if w == "uk" {
+			uk = true
+		}
+	}
+	if !uk || strings.Contains(place, "new south wales") {
+		return "", false
+	}
+
+	// Numbers of Scottish and Northern Irish companies start with a two letter prefix
+	prefix := ""
+	if len(number) > 2 && number[0] >= 'A' && number[0] <= 'Z' && number[1] >= 'A' && number[1] <= 'Z' {
+		prefix, number = number[:2], number[2:]
+	}
+	for _, r := range number {
+		if r < '0' || r > '9' {
+			return "", false
+		}
+	}
+	if len(prefix)+len(number) > 8 {
+		return "", false
+	}
+
+	return prefix + strings.Repeat("0", 8-len(prefix)-len(number)) + number, true
+}
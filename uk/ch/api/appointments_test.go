@@ -0,0 +1,57 @@
+package api_test
+
+import (
+	"net/url"
+	"testing"
+
+	ch "github.com/appinesshq/globire-go/uk/ch/api"
+	"github.com/appinesshq/globire-go/uk/ch/api/tests"
+)
+
+func TestGetOfficerAppointments(t *testing.T) {
+	api, err := ch.New("12345")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	ts := tests.NewMockServer()
+	api.URL, err = url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	a, err := api.OfficerAppointments("e4-ScyHpxNNUh6ZyV9wnqZS1kfY")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	if got, expected := len(a.Items), 2; got != expected {
+		t.Fatalf("expected %d appointments, but got %d", expected, got)
+	}
+
+	if got, expected := a.Items[1].AppointedTo.CompanyNumber, "87654321"; got != expected {
+		t.Fatalf("expected %q, but got %q", expected, got)
+	}
+}
+
+func TestUKCompanyNumber(t *testing.T) {
+	tests := []struct {
+		id       ch.Identification
+		expected string
+		ok       bool
+	}{
+		{ch.Identification{PlaceRegistered: "Companies House", RegistrationNumber: "87654321"}, "87654321", true},
+		{ch.Identification{CountryRegistered: "England And Wales", RegistrationNumber: "654321"}, "00654321", true},
+		{ch.Identification{PlaceRegistered: "Registrar of Companies for Scotland", RegistrationNumber: "SC12345"}, "SC012345", true},
+		{ch.Identification{CountryRegistered: "Ukraine", RegistrationNumber: "12345678"}, "", false},
+		{ch.Identification{CountryRegistered: "Netherlands", RegistrationNumber: "12345678"}, "", false},
+		{ch.Identification{CountryRegistered: "United Kingdom"}, "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := tt.id.UKCompanyNumber()
+		if got != tt.expected || ok != tt.ok {
+			t.Errorf("expected %q, %v for %+v, but got %q, %v", tt.expected, tt.ok, tt.id, got, ok)
+		}
+	}
+}
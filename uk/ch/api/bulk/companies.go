@@ -0,0 +1,354 @@
+// Package bulk reads the free bulk data products published by Companies House, like the
+// BasicCompanyData snapshot of all live companies and the PSC snapshot.
+package bulk
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/appinesshq/globire-go/uk/ch/api"
+	"github.com/pkg/errors"
+)
+
+// bulkDateLayout is the date format used in the BasicCompanyData product
+const bulkDateLayout = "02/01/2006"
+
+// Company contains a single record of the BasicCompanyData product. Company contains the
+// columns which map onto the API's company profile, the other fields the remaining columns.
+type Company struct {
+	Company api.Company
+
+	// SICText contains the SIC entries as published, e.g. "62012 - Business and domestic software development"
+	SICText []string
+
+	// Category is the free text company type, e.g. "Private Limited Company"
+	Category string
+
+	CountryOfOrigin string
+
+	Mortgages struct {
+		Charges       int
+		Outstanding   int
+		PartSatisfied int
+		Satisfied     int
+	}
+
+	LimitedPartnerships struct {
+		GeneralPartners int
+		LimitedPartners int
+	}
+
+	URI string
+}
+
+// CompanyReader reads BasicCompanyData records from a CSV file
+type CompanyReader struct {
+	r       *csv.Reader
+	columns map[string]int
+}
+
+// NewCompanyReader returns a CompanyReader, after reading the header of the CSV file
+func NewCompanyReader(r io.Reader) (*CompanyReader, error) {
+	cr := csv.NewReader(bufio.NewReader(r))
+	cr.ReuseRecord = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading header")
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, h := range header {
+		// Some column names start with a space, and the file may start with a byte order mark
+		columns[strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))] = i
+	}
+
+	for _, c := range []string{"CompanyName", "CompanyNumber"} {
+		if _, ok := columns[c]; !ok {
+			return nil, fmt.Errorf("missing column %s", c)
+		}
+	}
+
+	return &CompanyReader{r: cr, columns: columns}, nil
+}
+
+// Read returns the next record, or io.EOF if there are no more records.
+// If columns can't be parsed, the record is returned with the other columns filled in, together
+// with an error, so the caller can decide whether to skip it.
+func (cr *CompanyReader) Read() (*Company, error) {
+	rec, err := cr.r.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, err
+		}
+		return nil, errors.Wrap(err, "reading record")
+	}
+
+	var errs []string
+	get := func(col string) string {
+		i, ok := cr.columns[col]
+		if !ok || i >= len(rec) {
+			return ""
+		}
+		return strings.TrimSpace(rec[i])
+	}
+	date := func(col string) api.ChDate {
+		d, err := parseDate(get(col))
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", col, err))
+		}
+		return d
+	}
+	number := func(col string) int {
+		s := get(col)
+		if s == "" {
+			return 0
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", col, err))
+		}
+		return n
+	}
+
+	res := Company{
+		Category:        get("CompanyCategory"),
+		CountryOfOrigin: get("CountryOfOrigin"),
+		URI:             get("URI"),
+	}
+
+	c := &res.Company
+	c.Name = get("CompanyName")
+	c.CompanyNumber = get("CompanyNumber")
+	c.Links.Self = "/company/" + c.CompanyNumber
+	c.RegisteredOfficeAddress = api.Address{
+		CareOf:       get("RegAddress.CareOf"),
+		PoBox:        get("RegAddress.POBox"),
+		AddressLine1: get("RegAddress.AddressLine1"),
+		AddressLine2: get("RegAddress.AddressLine2"),
+		Locality:     get("RegAddress.PostTown"),
+		Region:       get("RegAddress.County"),
+		Country:      get("RegAddress.Country"),
+		PostalCode:   get("RegAddress.PostCode"),
+	}
+	c.Type = CompanyType(res.Category)
+	c.CompanyStatus, c.CompanyStatusDetail = CompanyStatus(get("CompanyStatus"))
+	c.DateOfCessation = date("DissolutionDate")
+	c.DateOfCreation = date("IncorporationDate")
+
+	if day, month := get("Accounts.AccountRefDay"), get("Accounts.AccountRefMonth"); day != "" && month != "" {
+		c.Accounts.AccountingReferenceDate = api.RefDate{Day: pad(day), Month: pad(month)}
+	}
+	c.Accounts.NextAccounts.DueOn = date("Accounts.NextDueDate")
+	c.Accounts.NextDue = c.Accounts.NextAccounts.DueOn
+	c.Accounts.LastAccounts.MadeUpTo = date("Accounts.LastMadeUpDate")
+	c.Accounts.LastAccounts.Type = AccountType(get("Accounts.AccountCategory"))
+	c.AnnualReturn.NextDue = date("Returns.NextDueDate")
+	c.AnnualReturn.LastMadeUpTo = date("Returns.LastMadeUpDate")
+	c.ConfirmationStatement.NextDue = date("ConfStmtNextDueDate")
+	c.ConfirmationStatement.LastMadeUpTo = date("ConfStmtLastMadeUpDate")
+
+	res.Mortgages.Charges = number("Mortgages.NumMortCharges")
+	res.Mortgages.Outstanding = number("Mortgages.NumMortOutstanding")
+	res.Mortgages.PartSatisfied = number("Mortgages.NumMortPartSatisfied")
+	res.Mortgages.Satisfied = number("Mortgages.NumMortSatisfied")
+	c.HasCharges = res.Mortgages.Charges > 0
+
+	res.LimitedPartnerships.GeneralPartners = number("LimitedPartnerships.NumGenPartners")
+	res.LimitedPartnerships.LimitedPartners = number("LimitedPartnerships.NumLimPartners")
+
+	for i := 1; i <= 4; i++ {
+		text := get(fmt.Sprintf("SICCode.SicText_%d", i))
+		if text == "" {
+			continue
+		}
+		res.SICText = append(res.SICText, text)
+		if code, ok := SICCode(text); ok {
+			c.SICCodes = append(c.SICCodes, code)
+		}
+	}
+
+	for i := 1; i <= 10; i++ {
+		name := get(fmt.Sprintf("PreviousName_%d.CompanyName", i))
+		if name == "" {
+			continue
+		}
+		c.PreviousCompanyNames = append(c.PreviousCompanyNames, api.PreviousName{
+			Name:     name,
+			CeasedOn: date(fmt.Sprintf("PreviousName_%d.CONDATE", i)),
+		})
+	}
+
+	if len(errs) > 0 {
+		return &res, fmt.Errorf("company %s: %s", c.CompanyNumber, strings.Join(errs, "; "))
+	}
+	return &res, nil
+}
+
+// ReadCompanies calls fn for every record in the CSV file, until fn returns an error
+func ReadCompanies(r io.Reader, fn func(*Company) error) error {
+	cr, err := NewCompanyReader(r)
+	if err != nil {
+		return err
+	}
+
+	for {
+		c, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(c); err != nil {
+			return err
+		}
+	}
+}
+
+// ReadCompaniesZip calls fn for every record in the CSV files of a zipped part of the
+// BasicCompanyData product, or of the single file containing all parts
+func ReadCompaniesZip(name string, fn func(*Company) error) error {
+	zr, err := zip.OpenReader(name)
+	if err != nil {
+		return errors.Wrap(err, "opening zip")
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		if !strings.EqualFold(path.Ext(f.Name), ".csv") {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return errors.Wrapf(err, "opening %s", f.Name)
+		}
+
+		err = ReadCompanies(rc, fn)
+		rc.Close()
+		if err != nil {
+			return errors.Wrap(err, f.Name)
+		}
+	}
+
+	return nil
+}
+
+// parseDate parses the dd/mm/yyyy dates of the bulk products
+func parseDate(s string) (api.ChDate, error) {
+	if s == "" {
+		return api.ChDate{}, nil
+	}
+	t, err := time.Parse(bulkDateLayout, s)
+	return api.ChDate{Time: t}, err
+}
+
+func pad(s string) string {
+	if len(s) == 1 {
+		return "0" + s
+	}
+	return s
+}
+
+var sicPattern = regexp.MustCompile(`^(\d{4,5})\b`)
+
+// SICCode returns the code from a free text SIC entry like "62012 - Business and domestic software development".
+// ok is false for entries without a code, like "None Supplied".
+func SICCode(text string) (api.SICCode, bool) {
+	m := sicPattern.FindStringSubmatch(strings.TrimSpace(text))
+	if m == nil {
+		return "", false
+	}
+	return api.SICCode(m[1]), true
+}
+
+// companyTypes maps the free text company categories to the company types of the API
+var companyTypes = map[string]api.CompanyType{
+	"private limited company":   "ltd",
+	"public limited company":    "plc",
+	"old public company":        "old-public-company",
+	"private unlimited company": "private-unlimited",
+	"private unlimited":         "private-unlimited",
+	"pri/ltd by guar/nsc (private, limited by guarantee, no share capital)":                     "private-limited-guarant-nsc",
+	"pri/lbg/nsc (private, limited by guarantee, no share capital, use of 'limited' exemption)": "private-limited-guarant-nsc-limited-exemption",
+	"priv ltd sect. 30 (private limited company, section 30 of the companies act)":              "private-limited-shares-section-30-exemption",
+	"private unlimited company without share capital":                                           "private-unlimited-nsc",
+	"limited partnership":                                   "limited-partnership",
+	"limited liability partnership":                         "llp",
+	"community interest company":                            "ltd",
+	"overseas entity":                                       "oversea-company",
+	"overseas company":                                      "oversea-company",
+	"investment company with variable capital":              "investment-company-with-variable-capital",
+	"investment company with variable capital(umbrella)":    "icvc-umbrella",
+	"investment company with variable capital (umbrella)":   "icvc-umbrella",
+	"investment company with variable capital(securities)":  "icvc-securities",
+	"investment company with variable capital (securities)": "icvc-securities",
+	"industrial and provident society":                      "industrial-and-provident-society",
+	"royal charter company":                                 "royal-charter",
+	"converted/closed":                                      "converted-or-closed",
+	"european public limited-liability company (se)":        "european-public-limited-liability-company-se",
+	"european economic interest grouping (eeig)":            "eeig",
+	"uk establishment company":                              "uk-establishment",
+	"unregistered company":                                  "unregistered-company",
+	"assurance company":                                     "assurance-company",
+	"other company type":                                    "other",
+}
+
+// CompanyType returns the company type of the API for a free text company category.
+// Categories without an equivalent are returned as "other".
+func CompanyType(category string) api.CompanyType {
+	if category == "" {
+		return ""
+	}
+	if t, ok := companyTypes[strings.ToLower(strings.TrimSpace(category))]; ok {
+		return t
+	}
+	return "other"
+}
+
+// CompanyStatus returns the company status and status detail of the API for a free text company status,
+// e.g. "Active - Proposal to Strike off"
+func CompanyStatus(status string) (api.CompanyStatus, api.CompanyStatusDetail) {
+	s := strings.ToLower(strings.TrimSpace(status))
+	switch {
+	case s == "":
+		return "", ""
+	case s == "active - proposal to strike off":
+		return "active", "active-proposal-to-strike-off"
+	case strings.HasPrefix(s, "active"), strings.HasPrefix(s, "live"):
+		return "active", ""
+	case strings.HasPrefix(s, "in administration"), s == "administration order":
+		return "administration", ""
+	case strings.HasPrefix(s, "voluntary arrangement"):
+		return "voluntary-arrangement", ""
+	case strings.Contains(s, "receiver"):
+		return "receivership", ""
+	case s == "converted/closed":
+		return "converted-closed", ""
+	default:
+		return api.CompanyStatus(strings.Join(strings.Fields(s), "-")), ""
+	}
+}
+
+// AccountType returns the account type of the API for a free text account category,
+// e.g. "TOTAL EXEMPTION FULL"
+func AccountType(category string) api.AccountType {
+	s := strings.ToLower(strings.TrimSpace(category))
+	switch s {
+	case "":
+		return ""
+	case "no accounts filed", "accounts type not available":
+		return "null"
+	default:
+		return api.AccountType(strings.Join(strings.Fields(s), "-"))
+	}
+}
@@ -0,0 +1,124 @@
+package bulk_test
+
+import (
+	"archive/zip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/appinesshq/globire-go/uk/ch/api/bulk"
+)
+
+const basicCompanyData = "\ufeffCompanyName, CompanyNumber,RegAddress.CareOf,RegAddress.POBox,RegAddress.AddressLine1, RegAddress.AddressLine2,RegAddress.PostTown,RegAddress.County,RegAddress.Country,RegAddress.PostCode,CompanyCategory,CompanyStatus,CountryOfOrigin,DissolutionDate,IncorporationDate,Accounts.AccountRefDay,Accounts.AccountRefMonth,Accounts.NextDueDate,Accounts.LastMadeUpDate,Accounts.AccountCategory,Returns.NextDueDate,Returns.LastMadeUpDate,Mortgages.NumMortCharges,Mortgages.NumMortOutstanding,Mortgages.NumMortPartSatisfied,Mortgages.NumMortSatisfied,SICCode.SicText_1,SICCode.SicText_2,SICCode.SicText_3,SICCode.SicText_4,LimitedPartnerships.NumGenPartners,LimitedPartnerships.NumLimPartners,URI,PreviousName_1.CONDATE, PreviousName_1.CompanyName,ConfStmtNextDueDate,ConfStmtLastMadeUpDate\n" +
+	`"TEST LTD","12345678","","","Office 1","15 Test Road","TEST TOWN","","UNITED KINGDOM","TS1 2TS","Private Limited Company","Active","United Kingdom","","25/06/2019","30","6","25/06/2021","","NO ACCOUNTS FILED","","","1","1","0","0","58290 - Other software publishing","62012 - Business and domestic software development","","","0","0","http://business.data.gov.uk/id/company/12345678","01/09/2020","OLD TEST LTD","05/08/2020",""` + "\n" +
+	`"GONE LTD","00000001","","","1 Road","","TOWN","","","AB1 2CD","PRI/LTD BY GUAR/NSC (Private, limited by guarantee, no share capital)","Active - Proposal to Strike off","United Kingdom","","01/01/2000","31","12","30/09/2020","31/12/2019","MICRO ENTITY","","","0","0","0","0","None Supplied","","","","0","0","http://business.data.gov.uk/id/company/00000001","","","",""` + "\n"
+
+func TestReadCompanies(t *testing.T) {
+	var res []*bulk.Company
+	err := bulk.ReadCompanies(strings.NewReader(basicCompanyData), func(c *bulk.Company) error {
+		res = append(res, c)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	if got, expected := len(res), 2; got != expected {
+		t.Fatalf("expected %d records, but got %d", expected, got)
+	}
+
+	c := res[0].Company
+	if got, expected := c.Name, "TEST LTD"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if got, expected := c.RegisteredOfficeAddress.AddressLine2, "15 Test Road"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if got, expected := c.Type, "ltd"; string(got) != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if got, expected := c.DateOfCreation.Format("2006-01-02"), "2019-06-25"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if got, expected := c.Accounts.AccountingReferenceDate.Month, "06"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if got, expected := len(c.SICCodes), 2; got != expected {
+		t.Fatalf("expected %d SIC codes, but got %d", expected, got)
+	}
+
+	if got, expected := string(c.SICCodes[1]), "62012"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if got, expected := c.HasCharges, true; got != expected {
+		t.Errorf("expected %v, but got %v", expected, got)
+	}
+
+	if got, expected := c.PreviousCompanyNames[0].Name, "OLD TEST LTD"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	c = res[1].Company
+	if got, expected := string(c.CompanyStatusDetail), "active-proposal-to-strike-off"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if got, expected := string(c.Type), "private-limited-guarant-nsc"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if got, expected := string(c.Accounts.LastAccounts.Type), "micro-entity"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if got, expected := len(c.SICCodes), 0; got != expected {
+		t.Errorf("expected %d SIC codes, but got %d", expected, got)
+	}
+
+	if got, expected := res[1].SICText[0], "None Supplied"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+}
+
+func TestReadCompaniesZip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bulk")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	name := filepath.Join(dir, "BasicCompanyData-2020-09-01-part1_6.zip")
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	zw := zip.NewWriter(f)
+	w, err := zw.Create("BasicCompanyData-2020-09-01-part1_6.csv")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	io.WriteString(w, basicCompanyData)
+	zw.Close()
+	f.Close()
+
+	count := 0
+	if err := bulk.ReadCompaniesZip(name, func(c *bulk.Company) error {
+		count++
+		return nil
+	}); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	if got, expected := count, 2; got != expected {
+		t.Errorf("expected %d records, but got %d", expected, got)
+	}
+}
@@ -0,0 +1,291 @@
+package bulk
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/appinesshq/globire-go/uk/ch/api"
+	"github.com/pkg/errors"
+)
+
+const (
+	pscStatementKind = "persons-with-significant-control-statement"
+	pscExemptionKind = "exemptions"
+	pscTotalsKind    = "totals#persons-of-significant-control-snapshot"
+)
+
+// PSCRecord contains a single line of the PSC snapshot. Depending on the kind of the line, either
+// PSC or Statement is set. Other kinds of lines, like exemptions, are only available as raw data.
+type PSCRecord struct {
+	CompanyNumber string
+	Kind          string
+	PSC           *api.PSC
+	Statement     *api.PSCStatement
+	Data          json.RawMessage
+}
+
+// PSCSummary contains the totals line at the end of the PSC snapshot
+type PSCSummary struct {
+	Kind                             string `json:"kind"`
+	PersonsOfSignificantControlCount int    `json:"persons_of_significant_control_count"`
+	StatementsCount                  int    `json:"statements_count"`
+	ExemptionsCount                  int    `json:"exemptions_count"`
+	GeneratedAt                      string `json:"generated_at"`
+}
+
+// pscLine is the envelope of every line of the PSC snapshot
+type pscLine struct {
+	CompanyNumber string          `json:"company_number"`
+	Data          json.RawMessage `json:"data"`
+}
+
+// PSCReader reads the newline delimited JSON of the PSC snapshot, one line at a time
+type PSCReader struct {
+	r    *bufio.Reader
+	line int
+
+	// Summary is set once the totals line has been read
+	Summary *PSCSummary
+}
+
+// NewPSCReader returns a PSCReader
+func NewPSCReader(r io.Reader) *PSCReader {
+	return &PSCReader{r: bufio.NewReaderSize(r, 64*1024)}
+}
+
+// Read returns the next record, or io.EOF if there are no more records.
+// The totals line isn't returned as a record, but stored in Summary.
+func (pr *PSCReader) Read() (*PSCRecord, error) {
+	for {
+		b, err := pr.next()
+		if err != nil {
+			return nil, err
+		}
+
+		rec, summary, err := decodePSCLine(b)
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", pr.line)
+		}
+		if summary != nil {
+			pr.Summary = summary
+			continue
+		}
+		return rec, nil
+	}
+}
+
+// next returns the next non-empty line. Lines aren't limited in length.
+func (pr *PSCReader) next() ([]byte, error) {
+	for {
+		b, err := pr.r.ReadBytes('\n')
+		if len(b) == 0 && err != nil {
+			return nil, err
+		}
+		pr.line++
+
+		if b = bytes.TrimSpace(b); len(b) > 0 {
+			return b, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// decodePSCLine decodes a line into a record, or a summary for the totals line
+func decodePSCLine(b []byte) (*PSCRecord, *PSCSummary, error) {
+	var l pscLine
+	if err := json.Unmarshal(b, &l); err != nil {
+		return nil, nil, errors.Wrap(err, "decoding line")
+	}
+
+	var kind struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(l.Data, &kind); err != nil {
+		return nil, nil, errors.Wrap(err, "decoding kind")
+	}
+
+	rec := PSCRecord{CompanyNumber: l.CompanyNumber, Kind: kind.Kind, Data: l.Data}
+	switch {
+	case kind.Kind == pscTotalsKind:
+		var s PSCSummary
+		if err := json.Unmarshal(l.Data, &s); err != nil {
+			return nil, nil, errors.Wrap(err, "decoding totals")
+		}
+		return nil, &s, nil
+
+	case kind.Kind == pscStatementKind:
+		rec.Statement = &api.PSCStatement{}
+		if err := json.Unmarshal(l.Data, rec.Statement); err != nil {
+			return nil, nil, errors.Wrap(err, "decoding statement")
+		}
+
+	case strings.HasSuffix(kind.Kind, "-with-significant-control"):
+		rec.PSC = &api.PSC{}
+		if err := json.Unmarshal(l.Data, rec.PSC); err != nil {
+			return nil, nil, errors.Wrap(err, "decoding person with significant control")
+		}
+	}
+
+	return &rec, nil, nil
+}
+
+// ReadPSCs calls fn for every record in the PSC snapshot, until fn returns an error.
+// The totals line is returned as the summary, or nil if the snapshot doesn't contain one.
+func ReadPSCs(r io.Reader, fn func(*PSCRecord) error) (*PSCSummary, error) {
+	pr := NewPSCReader(r)
+	for {
+		rec, err := pr.Read()
+		if err == io.EOF {
+			return pr.Summary, nil
+		}
+		if err != nil {
+			return pr.Summary, err
+		}
+		if err := fn(rec); err != nil {
+			return pr.Summary, err
+		}
+	}
+}
+
+// ReadPSCsParallel is like ReadPSCs, but decodes the lines with the provided number of workers.
+// fn is never called concurrently, but the records are passed in no particular order.
+// Memory use is bounded by the number of workers, not by the size of the snapshot.
+func ReadPSCsParallel(r io.Reader, workers int, fn func(*PSCRecord) error) (*PSCSummary, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	type line struct {
+		n int
+		b []byte
+	}
+
+	type result struct {
+		rec     *PSCRecord
+		summary *PSCSummary
+		err     error
+	}
+
+	pr := NewPSCReader(r)
+	lines := make(chan line, workers*4)
+	results := make(chan result, workers*4)
+	done := make(chan struct{})
+
+	// The reader is waited for before returning, as r may be closed afterwards
+	var readErr error
+	var reader sync.WaitGroup
+	reader.Add(1)
+	go func() {
+		defer reader.Done()
+		defer close(lines)
+		for {
+			b, err := pr.next()
+			if err != nil {
+				if err != io.EOF {
+					readErr = err
+				}
+				return
+			}
+			select {
+			case lines <- line{pr.line, b}:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for l := range lines {
+				rec, summary, err := decodePSCLine(l.b)
+				if err != nil {
+					err = errors.Wrapf(err, "line %d", l.n)
+				}
+				select {
+				case results <- result{rec, summary, err}:
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var summary *PSCSummary
+	var err error
+	for res := range results {
+		if err != nil {
+			continue
+		}
+		switch {
+		case res.err != nil:
+			err = res.err
+		case res.summary != nil:
+			summary = res.summary
+		default:
+			err = fn(res.rec)
+		}
+		if err != nil {
+			// Stop the reader and workers, and drain the results until they have exited
+			close(done)
+		}
+	}
+
+	reader.Wait()
+	if err == nil && readErr != nil {
+		err = errors.Wrap(readErr, "reading snapshot")
+	}
+	return summary, err
+}
+
+// ReadPSCsZip calls fn for every record in the files of the zipped PSC snapshot, or one of its parts.
+// If workers is more than 1, the lines are decoded in parallel as by ReadPSCsParallel.
+func ReadPSCsZip(name string, workers int, fn func(*PSCRecord) error) (*PSCSummary, error) {
+	zr, err := zip.OpenReader(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening zip")
+	}
+	defer zr.Close()
+
+	var summary *PSCSummary
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return summary, errors.Wrapf(err, "opening %s", f.Name)
+		}
+
+		var s *PSCSummary
+		if workers > 1 {
+			s, err = ReadPSCsParallel(rc, workers, fn)
+		} else {
+			s, err = ReadPSCs(rc, fn)
+		}
+		rc.Close()
+		if s != nil {
+			summary = s
+		}
+		if err != nil {
+			return summary, errors.Wrap(err, f.Name)
+		}
+	}
+
+	return summary, nil
+}
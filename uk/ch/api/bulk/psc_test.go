@@ -0,0 +1,141 @@
+package bulk_test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/appinesshq/globire-go/uk/ch/api/bulk"
+)
+
+const pscSnapshot = `{"company_number":"12345678","data":{"kind":"individual-person-with-significant-control","name":"Mr Test Person","name_elements":{"title":"Mr","forename":"Test","surname":"PERSON"},"natures_of_control":["ownership-of-shares-25-to-50-percent"],"notified_on":"2019-06-25","links":{"self":"/company/12345678/persons-with-significant-control/individual/AbCdEfGhIjKlMnOpQrStUvWxYz0"}}}
+{"company_number":"12345678","data":{"kind":"corporate-entity-person-with-significant-control","name":"PARENT LTD","identification":{"place_registered":"Companies House","registration_number":"87654321"},"natures_of_control":["ownership-of-shares-50-to-75-percent"],"notified_on":"2019-06-25"}}
+
+{"company_number":"00000001","data":{"kind":"persons-with-significant-control-statement","statement":"no-individual-or-entity-with-signficant-control","notified_on":"2016-04-06","links":{"self":"/company/00000001/persons-with-significant-control-statements/abc"}}}
+{"company_number":"00000002","data":{"kind":"exemptions","exemptions":{}}}
+{"data":{"kind":"totals#persons-of-significant-control-snapshot","persons_of_significant_control_count":2,"statements_count":1,"exemptions_count":1,"generated_at":"2020-09-01T03:00:00"}}
+`
+
+func TestReadPSCs(t *testing.T) {
+	var recs []*bulk.PSCRecord
+	summary, err := bulk.ReadPSCs(strings.NewReader(pscSnapshot), func(r *bulk.PSCRecord) error {
+		recs = append(recs, r)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	if got, expected := len(recs), 4; got != expected {
+		t.Fatalf("expected %d records, but got %d", expected, got)
+	}
+
+	if got, expected := recs[0].PSC.NameElements.Surname, "PERSON"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if got, expected := recs[1].PSC.IsCorporate(), true; got != expected {
+		t.Errorf("expected %v, but got %v", expected, got)
+	}
+
+	if got, expected := recs[2].Statement.Statement, "no-individual-or-entity-with-signficant-control"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if recs[3].PSC != nil || recs[3].Statement != nil || recs[3].Kind != "exemptions" {
+		t.Errorf("expected a raw exemptions record, but got %+v", recs[3])
+	}
+
+	if summary == nil {
+		t.Fatalf("expected a summary")
+	}
+	if got, expected := summary.PersonsOfSignificantControlCount, 2; got != expected {
+		t.Errorf("expected %d, but got %d", expected, got)
+	}
+}
+
+func TestReadPSCsParallel(t *testing.T) {
+	var b strings.Builder
+	for i := 0; i < 1000; i++ {
+		fmt.Fprintf(&b, `{"company_number":"%08d","data":{"kind":"individual-person-with-significant-control","name":"Person %d"}}`+"\n", i, i)
+	}
+	b.WriteString(`{"data":{"kind":"totals#persons-of-significant-control-snapshot","persons_of_significant_control_count":1000}}` + "\n")
+
+	var count int64
+	summary, err := bulk.ReadPSCsParallel(strings.NewReader(b.String()), 4, func(r *bulk.PSCRecord) error {
+		atomic.AddInt64(&count, 1)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	if got, expected := count, int64(1000); got != expected {
+		t.Errorf("expected %d records, but got %d", expected, got)
+	}
+
+	if summary == nil || summary.PersonsOfSignificantControlCount != 1000 {
+		t.Errorf("expected a summary with 1000 PSCs, but got %+v", summary)
+	}
+
+	stop := fmt.Errorf("stop")
+	if _, err := bulk.ReadPSCsParallel(strings.NewReader(b.String()), 4, func(r *bulk.PSCRecord) error {
+		return stop
+	}); err != stop {
+		t.Errorf("expected the error of fn, but got: %v", err)
+	}
+
+	invalid := pscSnapshot + "{not json}\n"
+	if _, err := bulk.ReadPSCsParallel(strings.NewReader(invalid), 2, func(r *bulk.PSCRecord) error {
+		return nil
+	}); err == nil || !strings.HasPrefix(err.Error(), "line 7: ") {
+		t.Errorf("expected an error for invalid JSON on line 7, but got: %v", err)
+	}
+}
+
+// slowReader returns a few bytes at a time, and records reads after it has been closed
+type slowReader struct {
+	r      io.Reader
+	mu     sync.Mutex
+	closed bool
+	late   bool
+}
+
+func (s *slowReader) Read(p []byte) (int, error) {
+	time.Sleep(5 * time.Millisecond)
+	s.mu.Lock()
+	s.late = s.late || s.closed
+	s.mu.Unlock()
+	if len(p) > 64 {
+		p = p[:64]
+	}
+	return s.r.Read(p)
+}
+
+func (s *slowReader) Close() {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+}
+
+func TestReadPSCsParallelStop(t *testing.T) {
+	sr := &slowReader{r: strings.NewReader(strings.Repeat(pscSnapshot, 5))}
+	stop := fmt.Errorf("stop")
+	if _, err := bulk.ReadPSCsParallel(sr, 2, func(r *bulk.PSCRecord) error {
+		return stop
+	}); err != stop {
+		t.Errorf("expected the error of fn, but got: %v", err)
+	}
+	sr.Close()
+
+	time.Sleep(20 * time.Millisecond)
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	if sr.late {
+		t.Errorf("expected the snapshot not to be read after returning")
+	}
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/appinesshq/globire-go/uk/ch/api/enum"
+)
+
+// ChargeStatus represents the status of a charge
+type ChargeStatus string
+
+// String implements the Stringer interface to get a human readable string from the CH enums
+func (f ChargeStatus) String() string {
+	return enum.MortgageDescriptions.Get("status", string(f))
+}
+
+// Validate returns an error if the value isn't a known code
+func (f ChargeStatus) Validate() error {
+	return validate(enum.MortgageDescriptions, "status", string(f))
+}
+
+// AssetsCeasedReleased represents the cessation or release of the property of a charge
+type AssetsCeasedReleased string
+
+// String implements the Stringer interface to get a human readable string from the CH enums
+func (f AssetsCeasedReleased) String() string {
+	return enum.MortgageDescriptions.Get("assets-ceased-released", string(f))
+}
+
+// Validate returns an error if the value isn't a known code. An empty value is valid, as the field is optional.
+func (f AssetsCeasedReleased) Validate() error {
+	return validateOptional(enum.MortgageDescriptions, "assets-ceased-released", string(f))
+}
+
+type (
+	// Charge contains the data of a charge registered against a company
+	Charge struct {
+		Etag                 string               `json:"etag"`
+		ID                   string               `json:"id"`
+		ChargeCode           string               `json:"charge_code"`
+		ChargeNumber         int                  `json:"charge_number"`
+		Status               ChargeStatus         `json:"status"`
+		AssetsCeasedReleased AssetsCeasedReleased `json:"assets_ceased_released"`
+		Classification       struct {
+			Type        string `json:"type"`
+			Description string `json:"description"`
+		} `json:"classification"`
+		AcquiredOn             ChDate `json:"acquired_on,omitzero"`
+		CoveringInstrumentDate ChDate `json:"covering_instrument_date,omitzero"`
+		CreatedOn              ChDate `json:"created_on,omitzero"`
+		DeliveredOn            ChDate `json:"delivered_on,omitzero"`
+		ResolvedOn             ChDate `json:"resolved_on,omitzero"`
+		SatisfiedOn            ChDate `json:"satisfied_on,omitzero"`
+		Particulars            struct {
+			Type                       string `json:"type"`
+			Description                string `json:"description"`
+			ContainsFixedCharge        bool   `json:"contains_fixed_charge"`
+			ContainsFloatingCharge     bool   `json:"contains_floating_charge"`
+			FloatingChargeCoversAll    bool   `json:"floating_charge_covers_all"`
+			ContainsNegativePledge     bool   `json:"contains_negative_pledge"`
+			ChargorActingAsBareTrustee bool   `json:"chargor_acting_as_bare_trustee"`
+		} `json:"particulars"`
+		SecuredDetails struct {
+			Type        string `json:"type"`
+			Description string `json:"description"`
+		} `json:"secured_details"`
+		MoreThanFourPersonsEntitled bool `json:"more_than_four_persons_entitled"`
+		PersonsEntitled             []struct {
+			Name string `json:"name"`
+		} `json:"persons_entitled"`
+		Transactions []struct {
+			FilingType           string                 `json:"filing_type"`
+			DeliveredOn          ChDate                 `json:"delivered_on,omitzero"`
+			InsolvencyCaseNumber string                 `json:"insolvency_case_number"`
+			Links                ChargeTransactionLinks `json:"links"`
+		} `json:"transactions"`
+		Links ChargeLinks `json:"links"`
+	}
+
+	// Charges contains the server response of a request for the charges of a company
+	Charges struct {
+		Etag               string   `json:"etag"`
+		TotalCount         int      `json:"total_count"`
+		UnfilteredCount    int      `json:"unfiltered_count"`
+		SatisfiedCount     int      `json:"satisfied_count"`
+		PartSatisfiedCount int      `json:"part_satisfied_count"`
+		Items              []Charge `json:"items"`
+	}
+)
+
+// IsOutstanding returns true if the charge hasn't been (fully) satisfied
+func (ch Charge) IsOutstanding() bool {
+	return ch.Status == "outstanding" || ch.Status == "part-satisfied"
+}
+
+// Charges gets and returns a company's charges
+// Possible options: ItemsPerPage, StartIndex
+func (c *Company) Charges(options ...Option) (*Charges, error) {
+	res := Charges{}
+	params := url.Values{}
+	for _, option := range options {
+		option(&params)
+	}
+
+	path := fmt.Sprintf("/company/%s/charges", c.CompanyNumber)
+	if err := c.api.get(c.CompanyNumber, ChargesResource, path, params, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -1,9 +1,7 @@
 package api
 
 import (
-	"context"
 	"fmt"
-	"net/http"
 
 	"github.com/appinesshq/globire-go/uk/ch/api/enum"
 	"github.com/pkg/errors"
@@ -17,6 +15,11 @@ func (f CompanyType) String() string {
 	return enum.Constants.Get("company_type", string(f))
 }
 
+// Validate returns an error if the value isn't a known code
+func (f CompanyType) Validate() error {
+	return validate(enum.Constants, "company_type", string(f))
+}
+
 // AccountType represents a type of company accounts
 type AccountType string
 
@@ -25,6 +28,11 @@ func (f AccountType) String() string {
 	return enum.Constants.Get("account_type", string(f))
 }
 
+// Validate returns an error if the value isn't a known code. An empty value is valid, as the field is optional.
+func (f AccountType) Validate() error {
+	return validateOptional(enum.Constants, "account_type", string(f))
+}
+
 // ForeignAccountType represents a type of company accounts for a foreign company
 type ForeignAccountType string
 
@@ -33,6 +41,11 @@ func (f ForeignAccountType) String() string {
 	return enum.Constants.Get("foreign_account_type", string(f))
 }
 
+// Validate returns an error if the value isn't a known code. An empty value is valid, as the field is optional.
+func (f ForeignAccountType) Validate() error {
+	return validateOptional(enum.Constants, "foreign_account_type", string(f))
+}
+
 // CompanyStatus represents the status of a company in the register
 type CompanyStatus string
 
@@ -41,6 +54,11 @@ func (f CompanyStatus) String() string {
 	return enum.Constants.Get("company_status", string(f))
 }
 
+// Validate returns an error if the value isn't a known code
+func (f CompanyStatus) Validate() error {
+	return validate(enum.Constants, "company_status", string(f))
+}
+
 // CompanyStatusDetail represents extra information on the company's status
 type CompanyStatusDetail string
 
@@ -49,6 +67,11 @@ func (f CompanyStatusDetail) String() string {
 	return enum.Constants.Get("company_status_detail", string(f))
 }
 
+// Validate returns an error if the value isn't a known code. An empty value is valid, as the field is optional.
+func (f CompanyStatusDetail) Validate() error {
+	return validateOptional(enum.Constants, "company_status_detail", string(f))
+}
+
 // TermsOfAccountPublication represents the terms related to a company's accounts
 type TermsOfAccountPublication string
 
@@ -57,6 +80,11 @@ func (f TermsOfAccountPublication) String() string {
 	return enum.Constants.Get("terms_of_account_publication", string(f))
 }
 
+// Validate returns an error if the value isn't a known code. An empty value is valid, as the field is optional.
+func (f TermsOfAccountPublication) Validate() error {
+	return validateOptional(enum.Constants, "terms_of_account_publication", string(f))
+}
+
 // Jurisdiction represents the jurisdiction of registration of a company
 type Jurisdiction string
 
@@ -65,6 +93,11 @@ func (f Jurisdiction) String() string {
 	return enum.Constants.Get("jurisdiction", string(f))
 }
 
+// Validate returns an error if the value isn't a known code. An empty value is valid, as the field is optional.
+func (f Jurisdiction) Validate() error {
+	return validateOptional(enum.Constants, "jurisdiction", string(f))
+}
+
 // PartialDataAvailable represents partial (not yet processed) data
 type PartialDataAvailable string
 
@@ -73,6 +106,11 @@ func (f PartialDataAvailable) String() string {
 	return enum.Constants.Get("partial_data_available", string(f))
 }
 
+// Validate returns an error if the value isn't a known code. An empty value is valid, as the field is optional.
+func (f PartialDataAvailable) Validate() error {
+	return validateOptional(enum.Constants, "partial_data_available", string(f))
+}
+
 // SICCode represents a Standard Industrial Classification code
 type SICCode string
 
@@ -85,12 +123,17 @@ func (f SICCode) String() string {
 	return fmt.Sprintf("%s - %s", string(f), desc)
 }
 
+// Validate returns an error if the value isn't a known code
+func (f SICCode) Validate() error {
+	return validate(enum.Constants, "sic_descriptions", string(f))
+}
+
 type (
 	// PreviousName struct contains data of a company's previous names and the time of use
 	PreviousName struct {
 		Name          string `json:"name"`
-		EffectiveFrom ChDate `json:"effective_from"`
-		CeasedOn      ChDate `json:"ceased_on"`
+		EffectiveFrom ChDate `json:"effective_from,omitzero"`
+		CeasedOn      ChDate `json:"ceased_on,omitzero"`
 	}
 
 	// RefDate struct consists of Day and Month
@@ -103,27 +146,27 @@ type (
 	Accounts struct {
 		AccountingReferenceDate RefDate `json:"accounting_reference_date"`
 		LastAccounts            struct {
-			MadeUpTo      ChDate      `json:"made_up_to"`
+			MadeUpTo      ChDate      `json:"made_up_to,omitzero"`
 			Type          AccountType `json:"type"`
-			PeriodEndOn   ChDate      `json:"period_end_on"`
-			PeriodStartOn ChDate      `json:"period_start_on"`
+			PeriodEndOn   ChDate      `json:"period_end_on,omitzero"`
+			PeriodStartOn ChDate      `json:"period_start_on,omitzero"`
 		} `json:"last_accounts"`
 		NextAccounts struct {
-			DueOn         ChDate `json:"due_on"`
+			DueOn         ChDate `json:"due_on,omitzero"`
 			Overdue       bool   `json:"overdue"`
-			PeriodEndOn   ChDate `json:"period_end_on"`
-			PeriodStartOn ChDate `json:"period_start_on"`
+			PeriodEndOn   ChDate `json:"period_end_on,omitzero"`
+			PeriodStartOn ChDate `json:"period_start_on,omitzero"`
 		} `json:"next_accounts"`
-		NextDue      ChDate `json:"next_due"`        // Deprecated. Please use next_accounts.due_on.
-		NextMadeUpTo ChDate `json:"next_made_up_to"` // Deprecated. Please use next_accounts.period_end_on.
-		Overdue      bool   `json:"overdue"`         // Deprecated. Please use next_accounts.overdue
+		NextDue      ChDate `json:"next_due,omitzero"`        // Deprecated. Please use next_accounts.due_on.
+		NextMadeUpTo ChDate `json:"next_made_up_to,omitzero"` // Deprecated. Please use next_accounts.period_end_on.
+		Overdue      bool   `json:"overdue"`                  // Deprecated. Please use next_accounts.overdue
 	}
 
 	// AnnualReturn struct contains a company's the last and next filing dates for the Annual Return
 	AnnualReturn struct {
-		LastMadeUpTo ChDate `json:"last_made_up_to"`
-		NextDue      ChDate `json:"next_due"`
-		NextMadeUpTo ChDate `json:"next_made_up_to"`
+		LastMadeUpTo ChDate `json:"last_made_up_to,omitzero"`
+		NextDue      ChDate `json:"next_due,omitzero"`
+		NextMadeUpTo ChDate `json:"next_made_up_to,omitzero"`
 		Overdue      bool   `json:"overdue"`
 	}
 
@@ -171,26 +214,17 @@ type (
 		CompanyStatus         CompanyStatus         `json:"company_status"`
 		CompanyStatusDetail   CompanyStatusDetail   `json:"company_status_detail"`
 		ConfirmationStatement AnnualReturn          `json:"confirmation_statement"`
-		DateOfCessation       ChDate                `json:"date_of_cessation"`
-		DateOfCreation        ChDate                `json:"date_of_creation"`
+		DateOfCessation       ChDate                `json:"date_of_cessation,omitzero"`
+		DateOfCreation        ChDate                `json:"date_of_creation,omitzero"`
 		Etag                  string                `json:"etag"`
 		ForeignCompanyDetails ForeignCompanyDetails `json:"foreign_company_details"`
 		HasBeenLiquidated     bool                  `json:"has_been_liquidated"`
 		HasCharges            bool                  `json:"has_charges"`
 		HasInsolvencyHistory  bool                  `json:"has_insolvency_history"`
 		// IsCommunityInterestCompany bool                  `json:"is_community_interest_company"`
-		Jurisdiction            Jurisdiction `json:"jurisdiction"`
-		LastFullMembersListDate ChDate       `json:"last_full_members_list_date"`
-		Links                   struct {
-			Charges                                 string `json:"charges"`
-			FilingHistory                           string `json:"filing_history"`
-			Insolvency                              string `json:"insolvency"`
-			Officers                                string `json:"officers"`
-			PersonsWithSignificantControl           string `json:"persons_with_significant_control"`
-			PersonsWithSignificantControlStatements string `json:"persons_with_significant_control_statements"`
-			Registers                               string `json:"registers"`
-			Self                                    string `json:"self"`
-		} `json:"links"`
+		Jurisdiction                Jurisdiction         `json:"jurisdiction"`
+		LastFullMembersListDate     ChDate               `json:"last_full_members_list_date,omitzero"`
+		Links                       CompanyLinks         `json:"links"`
 		PartialDataAvailable        PartialDataAvailable `json:"partial_data_available"`
 		PreviousCompanyNames        []PreviousName       `json:"previous_company_names"`
 		RegisteredOfficeAddress     Address              `json:"registered_office_address"`
@@ -209,11 +243,12 @@ func (c *Company) HasTasks() bool {
 }
 
 func (a *API) GetCompany(companyNumber string) (*Company, error) {
-	c := Company{api: a}
+	c := Company{}
 
-	if err := a.Do(context.Background(), http.MethodGet, "/company/"+companyNumber, nil, nil, &c); err != nil {
+	if err := a.get(companyNumber, CompanyResource, "/company/"+companyNumber, nil, &c); err != nil {
 		return nil, errors.Wrapf(err, "getting company")
 	}
+	c.api = a
 
 	return &c, nil
 }
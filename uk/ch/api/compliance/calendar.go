@@ -0,0 +1,105 @@
+package compliance
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/appinesshq/globire-go/uk/ch/api"
+)
+
+// Calendar is a list of deadlines across a portfolio of companies, ordered by due date
+type Calendar []Deadline
+
+// NewCalendar returns the deadlines of all provided companies
+func NewCalendar(now time.Time, companies ...*api.Company) Calendar {
+	var cal Calendar
+	for _, c := range companies {
+		cal = append(cal, Deadlines(c, now)...)
+	}
+
+	sort.SliceStable(cal, func(i, j int) bool { return cal[i].DueOn.Before(cal[j].DueOn) })
+	return cal
+}
+
+// Overdue returns the deadlines which have passed or are reported overdue by CH
+func (cal Calendar) Overdue() Calendar {
+	var res Calendar
+	for _, d := range cal {
+		if d.Overdue {
+			res = append(res, d)
+		}
+	}
+	return res
+}
+
+// Upcoming returns the deadlines which aren't overdue and are due within the provided number of days
+func (cal Calendar) Upcoming(now time.Time, days int) Calendar {
+	var res Calendar
+	for _, d := range cal {
+		if r := d.DaysRemaining(now); !d.Overdue && r >= 0 && r <= days {
+			res = append(res, d)
+		}
+	}
+	return res
+}
+
+// WriteICS writes the calendar as an iCalendar (RFC 5545) document with an all-day event per deadline
+func (cal Calendar) WriteICS(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+
+	writeLine(bw, "BEGIN:VCALENDAR")
+	writeLine(bw, "VERSION:2.0")
+	writeLine(bw, "PRODID:-//globire-go//Companies House deadlines//EN")
+	writeLine(bw, "CALSCALE:GREGORIAN")
+
+	for _, d := range cal {
+		summary := fmt.Sprintf("%s due: %s (%s)", d.Kind, d.CompanyName, d.CompanyNumber)
+		desc := fmt.Sprintf("%s for %s (%s) is due on %s.", d.Kind, d.CompanyName, d.CompanyNumber, d.DueOn.Format("2 January 2006"))
+		if !d.MadeUpTo.IsZero() {
+			desc += fmt.Sprintf(" Made up to %s.", d.MadeUpTo.Format("2 January 2006"))
+		}
+		if d.Overdue {
+			desc += " Overdue."
+		}
+
+		writeLine(bw, "BEGIN:VEVENT")
+		writeLine(bw, fmt.Sprintf("UID:%s-%s-%s@globire-go", d.CompanyNumber, d.Kind, d.DueOn.Format("20060102")))
+		writeLine(bw, "DTSTAMP:"+stamp)
+		writeLine(bw, "DTSTART;VALUE=DATE:"+d.DueOn.Format("20060102"))
+		writeLine(bw, "DTEND;VALUE=DATE:"+d.DueOn.AddDate(0, 0, 1).Format("20060102"))
+		writeLine(bw, "SUMMARY:"+escapeText(summary))
+		writeLine(bw, "DESCRIPTION:"+escapeText(desc))
+		writeLine(bw, "TRANSP:TRANSPARENT")
+		writeLine(bw, "END:VEVENT")
+	}
+
+	writeLine(bw, "END:VCALENDAR")
+	return bw.Flush()
+}
+
+// writeLine writes a content line terminated by CRLF, folded at 75 octets as required by RFC 5545
+func writeLine(w *bufio.Writer, line string) {
+	limit := 75
+	for len(line) > limit {
+		i := limit
+		// Don't split multi-byte characters
+		for i > 0 && line[i]&0xC0 == 0x80 {
+			i--
+		}
+		w.WriteString(line[:i] + "\r\n ")
+		line = line[i:]
+		limit = 74 // Continuation lines start with a space
+	}
+	w.WriteString(line + "\r\n")
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
@@ -0,0 +1,244 @@
+// Package compliance computes the statutory filing deadlines of companies from their
+// Companies House profile, and exports them as a calendar.
+package compliance
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/appinesshq/globire-go/uk/ch/api"
+)
+
+// Kind represents the kind of obligation a deadline belongs to
+type Kind string
+
+const (
+	// Accounts is the deadline for filing the annual accounts
+	Accounts Kind = "accounts"
+
+	// ConfirmationStatement is the deadline for filing the confirmation statement
+	ConfirmationStatement Kind = "confirmation-statement"
+
+	// AnnualReturn is the deadline for filing the annual return, which was replaced
+	// by the confirmation statement on 30 June 2016
+	AnnualReturn Kind = "annual-return"
+)
+
+// String returns a human readable description of the kind of obligation
+func (k Kind) String() string {
+	switch k {
+	case Accounts:
+		return "Accounts"
+	case ConfirmationStatement:
+		return "Confirmation statement"
+	case AnnualReturn:
+		return "Annual return"
+	default:
+		return string(k)
+	}
+}
+
+// Deadline represents a single filing obligation of a company
+type Deadline struct {
+	CompanyNumber string
+	CompanyName   string
+	Kind          Kind
+	MadeUpTo      time.Time // End of the period the filing covers
+	DueOn         time.Time
+	Overdue       bool
+	Computed      bool // True if DueOn was derived from the company's data instead of reported by CH
+}
+
+// DaysRemaining returns the number of days between now and the due date.
+// The result is negative for deadlines which have passed.
+func (d Deadline) DaysRemaining(now time.Time) int {
+	return int(truncate(d.DueOn).Sub(truncate(now)).Hours() / 24)
+}
+
+// Deadlines returns the upcoming and overdue obligations of a company, ordered by due date.
+// Dates reported by Companies House take precedence. If they are missing, the deadlines are
+// computed from the date of creation and the accounting reference date.
+func Deadlines(c *api.Company, now time.Time) []Deadline {
+	var res []Deadline
+	if !c.DateOfCessation.IsZero() {
+		return res
+	}
+
+	if d, ok := accountsDeadline(c); ok {
+		res = append(res, d)
+	}
+
+	if d, ok := confirmationStatementDeadline(c); ok {
+		res = append(res, d)
+	}
+
+	if d, ok := annualReturnDeadline(c); ok {
+		res = append(res, d)
+	}
+
+	for i := range res {
+		res[i].CompanyNumber = c.CompanyNumber
+		res[i].CompanyName = c.Name
+		if res[i].DaysRemaining(now) < 0 {
+			res[i].Overdue = true
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return res[i].DueOn.Before(res[j].DueOn) })
+	return res
+}
+
+func accountsDeadline(c *api.Company) (Deadline, bool) {
+	d := Deadline{Kind: Accounts, Overdue: c.Accounts.NextAccounts.Overdue || c.Accounts.Overdue}
+
+	switch {
+	case !c.Accounts.NextAccounts.DueOn.IsZero():
+		d.DueOn = c.Accounts.NextAccounts.DueOn.Time
+		d.MadeUpTo = c.Accounts.NextAccounts.PeriodEndOn.Time
+		return d, true
+	case !c.Accounts.NextDue.IsZero():
+		d.DueOn = c.Accounts.NextDue.Time
+		d.MadeUpTo = c.Accounts.NextMadeUpTo.Time
+		return d, true
+	}
+
+	day, month, ok := referenceDate(c.Accounts.AccountingReferenceDate)
+	if !ok {
+		return d, false
+	}
+	d.Computed = true
+
+	public := c.Type == "plc" || c.Type == "old-public-company"
+	filingMonths := 9
+	if public {
+		filingMonths = 6
+	}
+
+	// Subsequent accounts are due a fixed number of months after the end of the period.
+	if last := c.Accounts.LastAccounts.MadeUpTo; !last.IsZero() {
+		d.MadeUpTo = nextReferenceDate(last.Time, day, month)
+		d.DueOn = AddMonths(d.MadeUpTo, filingMonths)
+		return d, true
+	}
+
+	if c.DateOfCreation.IsZero() {
+		return d, false
+	}
+
+	// The first accounting reference period must be longer than 6 months and ends on the
+	// accounting reference date. If it is longer than 12 months, the accounts are due
+	// 21 (private) or 18 (public) months after incorporation, or 3 months after the end of
+	// the period, whichever is later.
+	created := c.DateOfCreation.Time
+	d.MadeUpTo = nextReferenceDate(AddMonths(created, 6), day, month)
+	if d.MadeUpTo.After(AddMonths(created, 12)) {
+		firstMonths := 21
+		if public {
+			firstMonths = 18
+		}
+		d.DueOn = AddMonths(created, firstMonths)
+		if alt := AddMonths(d.MadeUpTo, 3); alt.After(d.DueOn) {
+			d.DueOn = alt
+		}
+	} else {
+		d.DueOn = AddMonths(d.MadeUpTo, filingMonths)
+	}
+
+	return d, true
+}
+
+func confirmationStatementDeadline(c *api.Company) (Deadline, bool) {
+	cs := c.ConfirmationStatement
+	d := Deadline{Kind: ConfirmationStatement, Overdue: cs.Overdue}
+
+	switch {
+	case !cs.NextDue.IsZero():
+		d.DueOn = cs.NextDue.Time
+		d.MadeUpTo = cs.NextMadeUpTo.Time
+		return d, true
+	case !cs.NextMadeUpTo.IsZero():
+		d.MadeUpTo = cs.NextMadeUpTo.Time
+	case !cs.LastMadeUpTo.IsZero():
+		// Later review periods start the day after the last made up date and end 12 months later,
+		// on its anniversary
+		d.MadeUpTo = cs.LastMadeUpTo.AddDate(1, 0, 0)
+	case !c.DateOfCreation.IsZero() && c.AnnualReturn.NextDue.IsZero():
+		// The first review period starts on the date of incorporation, so it ends the day before its anniversary
+		d.MadeUpTo = c.DateOfCreation.AddDate(1, 0, -1)
+	default:
+		return d, false
+	}
+
+	// The confirmation statement must be delivered within 14 days after the review period.
+	d.Computed = true
+	d.DueOn = d.MadeUpTo.AddDate(0, 0, 14)
+	return d, true
+}
+
+func annualReturnDeadline(c *api.Company) (Deadline, bool) {
+	ar := c.AnnualReturn
+	if ar.NextDue.IsZero() || !c.ConfirmationStatement.LastMadeUpTo.IsZero() || !c.ConfirmationStatement.NextDue.IsZero() {
+		return Deadline{}, false
+	}
+
+	return Deadline{
+		Kind:     AnnualReturn,
+		MadeUpTo: ar.NextMadeUpTo.Time,
+		DueOn:    ar.NextDue.Time,
+		Overdue:  ar.Overdue,
+	}, true
+}
+
+// referenceDate converts the accounting reference date of a company to a day and month
+func referenceDate(rd api.RefDate) (int, time.Month, bool) {
+	day, err := strconv.Atoi(rd.Day)
+	if err != nil || day < 1 || day > 31 {
+		return 0, 0, false
+	}
+
+	month, err := strconv.Atoi(rd.Month)
+	if err != nil || month < 1 || month > 12 {
+		return 0, 0, false
+	}
+
+	return day, time.Month(month), true
+}
+
+// nextReferenceDate returns the first occurrence of the day and month strictly after t
+func nextReferenceDate(t time.Time, day int, month time.Month) time.Time {
+	for year := t.Year(); ; year++ {
+		d := clampDate(year, month, day, t.Location())
+		if d.After(t) {
+			return d
+		}
+	}
+}
+
+// AddMonths adds a number of months to t, keeping the day of the month where possible.
+// If t falls on the last day of its month, or the day doesn't exist in the target month,
+// the result is the last day of the target month, following the CH rules for filing periods.
+func AddMonths(t time.Time, months int) time.Time {
+	y, m, d := t.Date()
+	target := time.Date(y, m+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	if d == lastDay(y, m) {
+		d = 31
+	}
+	return clampDate(target.Year(), target.Month(), d, t.Location())
+}
+
+func clampDate(year int, month time.Month, day int, loc *time.Location) time.Time {
+	if last := lastDay(year, month); day > last {
+		day = last
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+func lastDay(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func truncate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
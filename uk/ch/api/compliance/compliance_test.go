@@ -0,0 +1,150 @@
+package compliance_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/appinesshq/globire-go/uk/ch/api"
+	"github.com/appinesshq/globire-go/uk/ch/api/compliance"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestDeadlinesReported(t *testing.T) {
+	var c api.Company
+	in := `{
+		"company_number": "12345678",
+		"company_name": "TEST LTD",
+		"type": "ltd",
+		"date_of_creation": "2019-06-25",
+		"accounts": {"next_accounts": {"due_on": "2021-06-25", "period_end_on": "2020-06-30"}},
+		"confirmation_statement": {"next_made_up_to": "2020-06-24", "next_due": "2020-08-05"}
+	}`
+	if err := json.Unmarshal([]byte(in), &c); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	ds := compliance.Deadlines(&c, date(2020, 7, 1))
+	if got, expected := len(ds), 2; got != expected {
+		t.Fatalf("expected %d deadlines, but got %d", expected, got)
+	}
+
+	if got, expected := ds[0].Kind, compliance.ConfirmationStatement; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if got, expected := ds[0].DaysRemaining(date(2020, 7, 1)), 35; got != expected {
+		t.Errorf("expected %d days remaining, but got %d", expected, got)
+	}
+
+	if got, expected := ds[1].DueOn, date(2021, 6, 25); !got.Equal(expected) {
+		t.Errorf("expected %v, but got %v", expected, got)
+	}
+
+	if got, expected := ds[0].Overdue, false; got != expected {
+		t.Errorf("expected overdue to be %v, but got %v", expected, got)
+	}
+
+	ds = compliance.Deadlines(&c, date(2020, 8, 6))
+	if got, expected := ds[0].Overdue, true; got != expected {
+		t.Errorf("expected overdue to be %v, but got %v", expected, got)
+	}
+}
+
+func TestDeadlinesComputed(t *testing.T) {
+	var c api.Company
+	in := `{
+		"company_number": "12345678",
+		"company_name": "TEST LTD",
+		"type": "ltd",
+		"date_of_creation": "2019-06-25",
+		"accounts": {"accounting_reference_date": {"month": "06", "day": "30"}}
+	}`
+	if err := json.Unmarshal([]byte(in), &c); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	ds := compliance.Deadlines(&c, date(2019, 7, 1))
+	if got, expected := len(ds), 2; got != expected {
+		t.Fatalf("expected %d deadlines, but got %d", expected, got)
+	}
+
+	cs, acc := ds[0], ds[1]
+	if got, expected := cs.MadeUpTo, date(2020, 6, 24); !got.Equal(expected) {
+		t.Errorf("expected confirmation statement made up to %v, but got %v", expected, got)
+	}
+	if got, expected := cs.DueOn, date(2020, 7, 8); !got.Equal(expected) {
+		t.Errorf("expected confirmation statement due on %v, but got %v", expected, got)
+	}
+
+	// The next review period starts the day after the last one
+	c.ConfirmationStatement.LastMadeUpTo = api.ChDate{Time: date(2020, 6, 24)}
+	for _, d := range compliance.Deadlines(&c, date(2020, 7, 1)) {
+		if d.Kind == compliance.ConfirmationStatement {
+			cs = d
+		}
+	}
+	if got, expected := cs.MadeUpTo, date(2021, 6, 24); !got.Equal(expected) {
+		t.Errorf("expected confirmation statement made up to %v, but got %v", expected, got)
+	}
+	if got, expected := cs.DueOn, date(2021, 7, 8); !got.Equal(expected) {
+		t.Errorf("expected confirmation statement due on %v, but got %v", expected, got)
+	}
+
+	// First period runs to 30 June 2020, longer than 12 months, so 21 months after incorporation applies.
+	if got, expected := acc.MadeUpTo, date(2020, 6, 30); !got.Equal(expected) {
+		t.Errorf("expected accounts made up to %v, but got %v", expected, got)
+	}
+
+	if got, expected := acc.DueOn, date(2021, 3, 25); !got.Equal(expected) {
+		t.Errorf("expected accounts due on %v, but got %v", expected, got)
+	}
+
+	if got, expected := acc.Computed, true; got != expected {
+		t.Errorf("expected computed to be %v, but got %v", expected, got)
+	}
+}
+
+func TestAddMonths(t *testing.T) {
+	tests := []struct {
+		in       time.Time
+		months   int
+		expected time.Time
+	}{
+		{date(2020, 6, 30), 9, date(2021, 3, 31)},
+		{date(2020, 8, 31), 6, date(2021, 2, 28)},
+		{date(2020, 6, 25), 21, date(2022, 3, 25)},
+	}
+
+	for _, tt := range tests {
+		if got := compliance.AddMonths(tt.in, tt.months); !got.Equal(tt.expected) {
+			t.Errorf("AddMonths(%v, %d): expected %v, but got %v", tt.in, tt.months, tt.expected, got)
+		}
+	}
+}
+
+func TestWriteICS(t *testing.T) {
+	cal := compliance.Calendar{{
+		CompanyNumber: "12345678",
+		CompanyName:   "TEST, LTD",
+		Kind:          compliance.Accounts,
+		DueOn:         date(2021, 6, 25),
+	}}
+
+	var buf bytes.Buffer
+	if err := cal.WriteICS(&buf); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	out := buf.String()
+	for _, s := range []string{"BEGIN:VCALENDAR\r\n", "DTSTART;VALUE=DATE:20210625\r\n", `SUMMARY:Accounts due: TEST\, LTD (12345678)`, "END:VCALENDAR\r\n"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected output to contain %q, but got:\n%s", s, out)
+		}
+	}
+}
@@ -0,0 +1,270 @@
+// Package diff compares two snapshots of a company and reports the changes between them.
+package diff
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/appinesshq/globire-go/uk/ch/api"
+)
+
+// Kind represents the kind of change between two snapshots
+type Kind string
+
+const (
+	// NameChanged is reported when the company name has changed
+	NameChanged Kind = "name-changed"
+
+	// StatusChanged is reported when the company status or status detail has changed
+	StatusChanged Kind = "status-changed"
+
+	// RegisteredOfficeMoved is reported when the registered office address has changed
+	RegisteredOfficeMoved Kind = "registered-office-moved"
+
+	// SICCodesChanged is reported when the nature of business has changed
+	SICCodesChanged Kind = "sic-codes-changed"
+
+	// AccountsFiled is reported when new accounts have been made up
+	AccountsFiled Kind = "accounts-filed"
+
+	// ConfirmationStatementFiled is reported when a new confirmation statement has been made up
+	ConfirmationStatementFiled Kind = "confirmation-statement-filed"
+
+	// OfficerAppointed is reported for officers which weren't in the previous snapshot
+	OfficerAppointed Kind = "officer-appointed"
+
+	// OfficerResigned is reported for officers who resigned since the previous snapshot
+	OfficerResigned Kind = "officer-resigned"
+
+	// PSCNotified is reported for persons with significant control which weren't in the previous snapshot
+	PSCNotified Kind = "psc-notified"
+
+	// PSCCeased is reported for persons with significant control who ceased since the previous snapshot
+	PSCCeased Kind = "psc-ceased"
+
+	// ChargeCreated is reported for charges which weren't in the previous snapshot
+	ChargeCreated Kind = "charge-created"
+
+	// ChargeStatusChanged is reported when a charge has been (partially) satisfied
+	ChargeStatusChanged Kind = "charge-status-changed"
+)
+
+// Change represents a single change between two snapshots.
+// Old and New contain a human readable representation of the changed value.
+// Officer, PSC and Charge are set for changes to the respective lists and point to the newer value.
+type Change struct {
+	Kind          Kind         `json:"kind"`
+	CompanyNumber string       `json:"company_number"`
+	Old           string       `json:"old,omitempty"`
+	New           string       `json:"new,omitempty"`
+	Date          time.Time    `json:"date,omitzero"` // Date the change took effect, if known
+	Officer       *api.Officer `json:"officer,omitempty"`
+	PSC           *api.PSC     `json:"person_with_significant_control,omitempty"`
+	Charge        *api.Charge  `json:"charge,omitempty"`
+}
+
+// Snapshot contains the state of a company at a point in time.
+// Lists which weren't retrieved should be left nil, so they're not compared.
+type Snapshot struct {
+	Company  *api.Company  `json:"company"`
+	Officers []api.Officer `json:"officers"`
+	PSCs     []api.PSC     `json:"persons_with_significant_control"`
+	Charges  []api.Charge  `json:"charges"`
+}
+
+// Compare returns the changes between two snapshots of the same company
+func Compare(old, new Snapshot) []Change {
+	var res []Change
+	if old.Company != nil && new.Company != nil {
+		res = append(res, Company(old.Company, new.Company)...)
+	}
+
+	number := ""
+	if new.Company != nil {
+		number = new.Company.CompanyNumber
+	}
+
+	if old.Officers != nil && new.Officers != nil {
+		res = append(res, Officers(number, old.Officers, new.Officers)...)
+	}
+
+	if old.PSCs != nil && new.PSCs != nil {
+		res = append(res, PSCs(number, old.PSCs, new.PSCs)...)
+	}
+
+	if old.Charges != nil && new.Charges != nil {
+		res = append(res, Charges(number, old.Charges, new.Charges)...)
+	}
+
+	return res
+}
+
+// Company returns the changes between two versions of a company profile
+func Company(old, new *api.Company) []Change {
+	var res []Change
+	add := func(k Kind, o, n string, d time.Time) {
+		res = append(res, Change{Kind: k, CompanyNumber: new.CompanyNumber, Old: o, New: n, Date: d})
+	}
+
+	if old.Name != new.Name {
+		var d time.Time
+		for _, p := range new.PreviousCompanyNames {
+			if p.Name == old.Name {
+				d = p.CeasedOn.Time
+			}
+		}
+		add(NameChanged, old.Name, new.Name, d)
+	}
+
+	if old.CompanyStatus != new.CompanyStatus || old.CompanyStatusDetail != new.CompanyStatusDetail {
+		add(StatusChanged, status(old), status(new), new.DateOfCessation.Time)
+	}
+
+	if o, n := address(old.RegisteredOfficeAddress), address(new.RegisteredOfficeAddress); o != n {
+		add(RegisteredOfficeMoved, o, n, time.Time{})
+	}
+
+	if o, n := sicCodes(old.SICCodes), sicCodes(new.SICCodes); o != n {
+		add(SICCodesChanged, o, n, time.Time{})
+	}
+
+	if o, n := old.Accounts.LastAccounts.MadeUpTo, new.Accounts.LastAccounts.MadeUpTo; !n.IsZero() && !o.Equal(n.Time) {
+		add(AccountsFiled, formatDate(o), formatDate(n), n.Time)
+	}
+
+	if o, n := old.ConfirmationStatement.LastMadeUpTo, new.ConfirmationStatement.LastMadeUpTo; !n.IsZero() && !o.Equal(n.Time) {
+		add(ConfirmationStatementFiled, formatDate(o), formatDate(n), n.Time)
+	}
+
+	return res
+}
+
+// Officers returns the appointments and resignations between two lists of officers
+func Officers(companyNumber string, old, new []api.Officer) []Change {
+	prev := make(map[string]api.Officer, len(old))
+	for _, o := range old {
+		prev[officerKey(o)] = o
+	}
+
+	var res []Change
+	for i := range new {
+		o := &new[i]
+		p, ok := prev[officerKey(*o)]
+		switch {
+		case !ok && o.ResignedOn.IsZero():
+			res = append(res, Change{Kind: OfficerAppointed, CompanyNumber: companyNumber, New: o.Name, Date: o.AppointedOn.Time, Officer: o})
+		case !o.ResignedOn.IsZero() && (!ok || p.ResignedOn.IsZero()):
+			res = append(res, Change{Kind: OfficerResigned, CompanyNumber: companyNumber, Old: o.Name, Date: o.ResignedOn.Time, Officer: o})
+		}
+	}
+
+	return sortByDate(res)
+}
+
+// PSCs returns the notifications and cessations between two lists of persons with significant control
+func PSCs(companyNumber string, old, new []api.PSC) []Change {
+	prev := make(map[string]api.PSC, len(old))
+	for _, p := range old {
+		prev[pscKey(p)] = p
+	}
+
+	var res []Change
+	for i := range new {
+		p := &new[i]
+		o, ok := prev[pscKey(*p)]
+		ceased := p.Ceased || !p.CeasedOn.IsZero()
+		switch {
+		case !ok && !ceased:
+			res = append(res, Change{Kind: PSCNotified, CompanyNumber: companyNumber, New: p.Name, Date: p.NotifiedOn.Time, PSC: p})
+		case ceased && (!ok || !(o.Ceased || !o.CeasedOn.IsZero())):
+			res = append(res, Change{Kind: PSCCeased, CompanyNumber: companyNumber, Old: p.Name, Date: p.CeasedOn.Time, PSC: p})
+		}
+	}
+
+	return sortByDate(res)
+}
+
+// Charges returns the new charges and status changes between two lists of charges
+func Charges(companyNumber string, old, new []api.Charge) []Change {
+	prev := make(map[string]api.Charge, len(old))
+	for _, c := range old {
+		prev[chargeKey(c)] = c
+	}
+
+	var res []Change
+	for i := range new {
+		c := &new[i]
+		o, ok := prev[chargeKey(*c)]
+		switch {
+		case !ok:
+			res = append(res, Change{Kind: ChargeCreated, CompanyNumber: companyNumber, New: c.Status.String(), Date: c.CreatedOn.Time, Charge: c})
+		case o.Status != c.Status:
+			res = append(res, Change{Kind: ChargeStatusChanged, CompanyNumber: companyNumber, Old: o.Status.String(), New: c.Status.String(), Date: c.SatisfiedOn.Time, Charge: c})
+		}
+	}
+
+	return sortByDate(res)
+}
+
+func officerKey(o api.Officer) string {
+	// Officers listed without appointment links can only be matched on their name
+	id := o.Links.Officer.Appointments
+	if id == "" {
+		id = o.Name
+	}
+	return id + "|" + string(o.OfficerRole) + "|" + o.AppointedOn.Format("2006-01-02")
+}
+
+func pscKey(p api.PSC) string {
+	if p.Links.Self != "" {
+		return p.Links.Self
+	}
+	return string(p.Kind) + "|" + p.Name + "|" + p.NotifiedOn.Format("2006-01-02")
+}
+
+func chargeKey(c api.Charge) string {
+	if c.ID != "" {
+		return c.ID
+	}
+	return c.ChargeCode
+}
+
+func status(c *api.Company) string {
+	s := string(c.CompanyStatus)
+	if c.CompanyStatusDetail != "" {
+		s += " (" + string(c.CompanyStatusDetail) + ")"
+	}
+	return s
+}
+
+func address(a api.Address) string {
+	parts := []string{a.CareOf, a.PoBox, a.Premises, a.AddressLine1, a.AddressLine2, a.Locality, a.Region, a.PostalCode, a.Country}
+	var res []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			res = append(res, p)
+		}
+	}
+	return strings.Join(res, ", ")
+}
+
+func sicCodes(codes []api.SICCode) string {
+	s := make([]string, len(codes))
+	for i, c := range codes {
+		s[i] = string(c)
+	}
+	sort.Strings(s)
+	return strings.Join(s, ", ")
+}
+
+// formatDate returns the date in the CH format, or an empty string for zero dates
+func formatDate(d api.ChDate) string {
+	b, _ := d.MarshalText()
+	return string(b)
+}
+
+func sortByDate(res []Change) []Change {
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Date.Before(res[j].Date) })
+	return res
+}
@@ -0,0 +1,111 @@
+package diff_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/appinesshq/globire-go/uk/ch/api"
+	"github.com/appinesshq/globire-go/uk/ch/api/diff"
+)
+
+func decode(t *testing.T, s string, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal([]byte(s), v); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+}
+
+func kinds(changes []diff.Change) map[diff.Kind]diff.Change {
+	res := make(map[diff.Kind]diff.Change)
+	for _, c := range changes {
+		res[c.Kind] = c
+	}
+	return res
+}
+
+func TestCompareCompany(t *testing.T) {
+	var old, new api.Company
+	decode(t, `{
+		"company_number": "12345678",
+		"company_name": "TEST LTD",
+		"company_status": "active",
+		"registered_office_address": {"address_line_1": "Office 1", "postal_code": "TS1 2TS"},
+		"sic_codes": ["58290", "62012"]
+	}`, &old)
+	decode(t, `{
+		"company_number": "12345678",
+		"company_name": "NEW TEST LTD",
+		"company_status": "liquidation",
+		"registered_office_address": {"address_line_1": "Office 2", "postal_code": "TS1 2TS"},
+		"sic_codes": ["62012", "58290"],
+		"accounts": {"last_accounts": {"made_up_to": "2020-06-30"}},
+		"previous_company_names": [{"name": "TEST LTD", "effective_from": "2019-06-25", "ceased_on": "2020-09-01"}]
+	}`, &new)
+
+	changes := kinds(diff.Company(&old, &new))
+	if got, expected := len(changes), 4; got != expected {
+		t.Fatalf("expected %d changes, but got %d: %+v", expected, got, changes)
+	}
+
+	if got, expected := changes[diff.NameChanged].New, "NEW TEST LTD"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if got, expected := changes[diff.NameChanged].Date.Format("2006-01-02"), "2020-09-01"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if got, expected := changes[diff.StatusChanged].Old, "active"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if got, expected := changes[diff.RegisteredOfficeMoved].New, "Office 2, TS1 2TS"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if got, expected := changes[diff.AccountsFiled].New, "2020-06-30"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if _, ok := changes[diff.SICCodesChanged]; ok {
+		t.Errorf("expected reordered SIC codes not to be reported")
+	}
+}
+
+func TestCompareLists(t *testing.T) {
+	var old, new diff.Snapshot
+	decode(t, `{
+		"company": {"company_number": "12345678"},
+		"officers": [
+			{"name": "PERSON, Test", "officer_role": "director", "appointed_on": "2019-06-25", "links": {"officer": {"appointments": "/officers/1/appointments"}}}
+		],
+		"persons_with_significant_control": [],
+		"charges": [{"id": "a", "status": "outstanding"}]
+	}`, &old)
+	decode(t, `{
+		"company": {"company_number": "12345678"},
+		"officers": [
+			{"name": "PERSON, Test", "officer_role": "director", "appointed_on": "2019-06-25", "resigned_on": "2020-01-01", "links": {"officer": {"appointments": "/officers/1/appointments"}}},
+			{"name": "OTHER, Test", "officer_role": "director", "appointed_on": "2020-01-01", "links": {"officer": {"appointments": "/officers/2/appointments"}}}
+		],
+		"persons_with_significant_control": [
+			{"name": "Mr Test Person", "kind": "individual-person-with-significant-control", "notified_on": "2020-01-01", "links": {"self": "/company/12345678/persons-with-significant-control/individual/1"}}
+		],
+		"charges": [{"id": "a", "status": "fully-satisfied"}, {"id": "b", "status": "outstanding"}]
+	}`, &new)
+
+	changes := kinds(diff.Compare(old, new))
+	for _, k := range []diff.Kind{diff.OfficerAppointed, diff.OfficerResigned, diff.PSCNotified, diff.ChargeCreated, diff.ChargeStatusChanged} {
+		if _, ok := changes[k]; !ok {
+			t.Errorf("expected a %q change", k)
+		}
+	}
+
+	if got, expected := changes[diff.OfficerAppointed].Officer.Name, "OTHER, Test"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if got, expected := changes[diff.ChargeStatusChanged].New, "Satisfied"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+}
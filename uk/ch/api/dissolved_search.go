@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// DissolvedSearchType represents the way a dissolved company search matches the query
+type DissolvedSearchType string
+
+const (
+	// DissolvedBestMatch matches the query against the last names of dissolved companies
+	DissolvedBestMatch DissolvedSearchType = "best-match"
+
+	// PreviousNameDissolved matches the query against all names of dissolved companies
+	PreviousNameDissolved DissolvedSearchType = "previous-name-dissolved"
+
+	// DissolvedAlphabetical returns the dissolved companies around the name in alphabetical order
+	DissolvedAlphabetical DissolvedSearchType = "alphabetical"
+)
+
+type (
+	// DissolvedSearchResult contains a single company returned by a dissolved company search
+	DissolvedSearchResult struct {
+		CompanyName     string        `json:"company_name"`
+		CompanyNumber   string        `json:"company_number"`
+		CompanyStatus   CompanyStatus `json:"company_status"`
+		DateOfCessation ChDate        `json:"date_of_cessation,omitzero"`
+		DateOfCreation  ChDate        `json:"date_of_creation,omitzero"`
+		Kind            string        `json:"kind"`
+
+		// MatchedPreviousCompanyName is the previous name matching the query of a PreviousNameDissolved search
+		MatchedPreviousCompanyName PreviousName `json:"matched_previous_company_name"`
+
+		// OrderedAlphaKeyWithID is the cursor for SearchAbove and SearchBelow in a DissolvedAlphabetical search
+		OrderedAlphaKeyWithID   string         `json:"ordered_alpha_key_with_id"`
+		PreviousCompanyNames    []PreviousName `json:"previous_company_names"`
+		RegisteredOfficeAddress Address        `json:"registered_office_address"`
+	}
+
+	// DissolvedSearch contains the server response of a dissolved company search
+	DissolvedSearch struct {
+		Etag   string                  `json:"etag"`
+		Hits   int                     `json:"hits"`
+		Items  []DissolvedSearchResult `json:"items"`
+		Kind   string                  `json:"kind"`
+		TopHit DissolvedSearchResult   `json:"top_hit"`
+	}
+)
+
+// DissolvedSearchCompanies searches for dissolved companies by name
+// Possible options: Size, StartIndex, SearchAbove and SearchBelow (DissolvedAlphabetical only)
+func (a *API) DissolvedSearchCompanies(ctx context.Context, query string, searchType DissolvedSearchType, options ...Option) (*DissolvedSearch, error) {
+	res := DissolvedSearch{}
+	params := url.Values{}
+	for _, option := range options {
+		option(&params)
+	}
+	params.Set("q", query)
+	params.Set("search_type", string(searchType))
+
+	if err := a.Do(ctx, http.MethodGet, "/dissolved-search/companies", params, nil, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -17,20 +17,68 @@ Copyright 2018 Foundation for Open Software Development (www.fosdev.org)
 package enum
 
 import (
+	"sort"
+	"strings"
+
 	"gopkg.in/yaml.v2"
 )
 
+//go:generate go run ../../../../cmd/enumgen -src ${CH_API_ENUMERATIONS}
+
 type ENUM map[string]map[string]string
 
+// Get returns the description of key k in section s, or an empty string if it doesn't exist
 func (y ENUM) Get(s, k string) string {
-	v, ok := y[string(s)][string(k)]
-	if !ok {
-		return ""
-	}
+	v, _ := y.Lookup(s, k)
 	return v
 }
 
-var Constants, FilingHistoryDescriptions, MortgageDescriptions, DisqualifiedOfficerDescriptions ENUM
+// Lookup returns the description of key k in section s, and whether it exists
+func (y ENUM) Lookup(s, k string) (string, bool) {
+	v, ok := y[s][k]
+	return v, ok
+}
+
+// Has returns true if key k exists in section s
+func (y ENUM) Has(s, k string) bool {
+	_, ok := y[s][k]
+	return ok
+}
+
+// Keys returns the sorted keys of section s
+func (y ENUM) Keys(s string) []string {
+	keys := make([]string, 0, len(y[s]))
+	for k := range y[s] {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Sections returns the sorted names of all sections
+func (y ENUM) Sections() []string {
+	sections := make([]string, 0, len(y))
+	for s := range y {
+		sections = append(sections, s)
+	}
+	sort.Strings(sections)
+	return sections
+}
+
+// Reverse returns the key in section s with description d, and whether it exists.
+// Descriptions are compared case insensitively and without surrounding spaces;
+// if several keys have the description, the first in sorted order is returned.
+func (y ENUM) Reverse(s, d string) (string, bool) {
+	d = strings.TrimSpace(d)
+	for _, k := range y.Keys(s) {
+		if strings.EqualFold(strings.TrimSpace(y[s][k]), d) {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+var Constants, FilingHistoryDescriptions, MortgageDescriptions, DisqualifiedOfficerDescriptions, SICHierarchy ENUM
 
 func init() {
 	if err := yaml.Unmarshal([]byte(filingHistoryDescriptionsYAML), &FilingHistoryDescriptions); err != nil {
@@ -48,4 +96,8 @@ func init() {
 	if err := yaml.Unmarshal([]byte(disqualifiedOfficerDescriptionsYAML), &DisqualifiedOfficerDescriptions); err != nil {
 		panic(err)
 	}
+
+	if err := yaml.Unmarshal([]byte(sicHierarchyYAML), &SICHierarchy); err != nil {
+		panic(err)
+	}
 }
@@ -0,0 +1,68 @@
+package enum
+
+import (
+	"io/fs"
+	"os"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Files maps the file names of the upstream api-enumerations repository to the tables they're loaded into
+var Files = map[string]*ENUM{
+	"constants.yml":                         &Constants,
+	"filing_history_descriptions.yml":       &FilingHistoryDescriptions,
+	"mortgage_descriptions.yml":             &MortgageDescriptions,
+	"disqualified_officer_descriptions.yml": &DisqualifiedOfficerDescriptions,
+}
+
+// Merge adds the sections and keys of o to y, replacing the descriptions of existing keys
+func (y ENUM) Merge(o ENUM) {
+	for s, keys := range o {
+		if y[s] == nil {
+			y[s] = make(map[string]string, len(keys))
+		}
+		for k, v := range keys {
+			y[s][k] = v
+		}
+	}
+}
+
+// MergeYAML decodes a table in the api-enumerations format and merges it into y
+func (y ENUM) MergeYAML(b []byte) error {
+	var o ENUM
+	if err := yaml.Unmarshal(b, &o); err != nil {
+		return errors.Wrap(err, "decoding YAML")
+	}
+	y.Merge(o)
+	return nil
+}
+
+// LoadFS merges the tables in fsys, named as in Files, into the built-in tables.
+// Files which don't exist are skipped, so only the tables to patch need to be provided.
+// LoadFS isn't safe for concurrent use with lookups, so it should be called at startup.
+func LoadFS(fsys fs.FS) error {
+	for name, table := range Files {
+		b, err := fs.ReadFile(fsys, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "reading %s", name)
+		}
+
+		if *table == nil {
+			*table = ENUM{}
+		}
+		if err := table.MergeYAML(b); err != nil {
+			return errors.Wrapf(err, "loading %s", name)
+		}
+	}
+	return nil
+}
+
+// LoadDir merges the tables in a directory, e.g. a checkout of the api-enumerations repository,
+// into the built-in tables. See LoadFS.
+func LoadDir(dir string) error {
+	return LoadFS(os.DirFS(dir))
+}
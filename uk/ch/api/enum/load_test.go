@@ -0,0 +1,37 @@
+package enum_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/appinesshq/globire-go/uk/ch/api/enum"
+)
+
+func TestLoadFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"constants.yml": &fstest.MapFile{Data: []byte("company_status:\n    'new-status' : \"New status\"\n    'active' : \"Trading\"\nnew_section:\n    'a' : \"A\"\n")},
+	}
+
+	before := enum.Constants.Get("company_status", "dissolved")
+	if err := enum.LoadFS(fsys); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	tt := []struct {
+		section, key, expected string
+	}{
+		{"company_status", "new-status", "New status"},
+		{"company_status", "active", "Trading"},
+		{"company_status", "dissolved", before},
+		{"new_section", "a", "A"},
+	}
+	for _, tc := range tt {
+		if got := enum.Constants.Get(tc.section, tc.key); got != tc.expected {
+			t.Errorf("expected %q, but got %q", tc.expected, got)
+		}
+	}
+
+	if err := enum.LoadFS(fstest.MapFS{"mortgage_descriptions.yml": &fstest.MapFile{Data: []byte("status: [")}}); err == nil {
+		t.Errorf("expected invalid YAML to fail")
+	}
+}
@@ -0,0 +1,420 @@
+package enum
+
+// sicHierarchyYAML contains the sections, divisions, groups and classes of the UK Standard Industrial Classification 2007.
+// Groups with a single class and classes with a single subclass are left out, as they share its description.
+const sicHierarchyYAML string = `---
+sic_sections:
+    'A' : "Agriculture, forestry and fishing"
+    'B' : "Mining and quarrying"
+    'C' : "Manufacturing"
+    'D' : "Electricity, gas, steam and air conditioning supply"
+    'E' : "Water supply; sewerage, waste management and remediation activities"
+    'F' : "Construction"
+    'G' : "Wholesale and retail trade; repair of motor vehicles and motorcycles"
+    'H' : "Transportation and storage"
+    'I' : "Accommodation and food service activities"
+    'J' : "Information and communication"
+    'K' : "Financial and insurance activities"
+    'L' : "Real estate activities"
+    'M' : "Professional, scientific and technical activities"
+    'N' : "Administrative and support service activities"
+    'O' : "Public administration and defence; compulsory social security"
+    'P' : "Education"
+    'Q' : "Human health and social work activities"
+    'R' : "Arts, entertainment and recreation"
+    'S' : "Other service activities"
+    'T' : "Activities of households as employers; undifferentiated goods- and services-producing activities of households for own use"
+    'U' : "Activities of extraterritorial organisations and bodies"
+sic_divisions:
+    '01' : "Crop and animal production, hunting and related service activities"
+    '02' : "Forestry and logging"
+    '03' : "Fishing and aquaculture"
+    '05' : "Mining of coal and lignite"
+    '06' : "Extraction of crude petroleum and natural gas"
+    '07' : "Mining of metal ores"
+    '08' : "Other mining and quarrying"
+    '09' : "Mining support service activities"
+    '10' : "Manufacture of food products"
+    '11' : "Manufacture of beverages"
+    '12' : "Manufacture of tobacco products"
+    '13' : "Manufacture of textiles"
+    '14' : "Manufacture of wearing apparel"
+    '15' : "Manufacture of leather and related products"
+    '16' : "Manufacture of wood and of products of wood and cork, except furniture; manufacture of articles of straw and plaiting materials"
+    '17' : "Manufacture of paper and paper products"
+    '18' : "Printing and reproduction of recorded media"
+    '19' : "Manufacture of coke and refined petroleum products"
+    '20' : "Manufacture of chemicals and chemical products"
+    '21' : "Manufacture of basic pharmaceutical products and pharmaceutical preparations"
+    '22' : "Manufacture of rubber and plastic products"
+    '23' : "Manufacture of other non-metallic mineral products"
+    '24' : "Manufacture of basic metals"
+    '25' : "Manufacture of fabricated metal products, except machinery and equipment"
+    '26' : "Manufacture of computer, electronic and optical products"
+    '27' : "Manufacture of electrical equipment"
+    '28' : "Manufacture of machinery and equipment n.e.c."
+    '29' : "Manufacture of motor vehicles, trailers and semi-trailers"
+    '30' : "Manufacture of other transport equipment"
+    '31' : "Manufacture of furniture"
+    '32' : "Other manufacturing"
+    '33' : "Repair and installation of machinery and equipment"
+    '35' : "Electricity, gas, steam and air conditioning supply"
+    '36' : "Water collection, treatment and supply"
+    '37' : "Sewerage"
+    '38' : "Waste collection, treatment and disposal activities; materials recovery"
+    '39' : "Remediation activities and other waste management services"
+    '41' : "Construction of buildings"
+    '42' : "Civil engineering"
+    '43' : "Specialised construction activities"
+    '45' : "Wholesale and retail trade and repair of motor vehicles and motorcycles"
+    '46' : "Wholesale trade, except of motor vehicles and motorcycles"
+    '47' : "Retail trade, except of motor vehicles and motorcycles"
+    '49' : "Land transport and transport via pipelines"
+    '50' : "Water transport"
+    '51' : "Air transport"
+    '52' : "Warehousing and support activities for transportation"
+    '53' : "Postal and courier activities"
+    '55' : "Accommodation"
+    '56' : "Food and beverage service activities"
+    '58' : "Publishing activities"
+    '59' : "Motion picture, video and television programme production, sound recording and music publishing activities"
+    '60' : "Programming and broadcasting activities"
+    '61' : "Telecommunications"
+    '62' : "Computer programming, consultancy and related activities"
+    '63' : "Information service activities"
+    '64' : "Financial service activities, except insurance and pension funding"
+    '65' : "Insurance, reinsurance and pension funding, except compulsory social security"
+    '66' : "Activities auxiliary to financial services and insurance activities"
+    '68' : "Real estate activities"
+    '69' : "Legal and accounting activities"
+    '70' : "Activities of head offices; management consultancy activities"
+    '71' : "Architectural and engineering activities; technical testing and analysis"
+    '72' : "Scientific research and development"
+    '73' : "Advertising and market research"
+    '74' : "Other professional, scientific and technical activities"
+    '75' : "Veterinary activities"
+    '77' : "Rental and leasing activities"
+    '78' : "Employment activities"
+    '79' : "Travel agency, tour operator and other reservation service and related activities"
+    '80' : "Security and investigation activities"
+    '81' : "Services to buildings and landscape activities"
+    '82' : "Office administrative, office support and other business support activities"
+    '84' : "Public administration and defence; compulsory social security"
+    '85' : "Education"
+    '86' : "Human health activities"
+    '87' : "Residential care activities"
+    '88' : "Social work activities without accommodation"
+    '90' : "Creative, arts and entertainment activities"
+    '91' : "Libraries, archives, museums and other cultural activities"
+    '92' : "Gambling and betting activities"
+    '93' : "Sports activities and amusement and recreation activities"
+    '94' : "Activities of membership organisations"
+    '95' : "Repair of computers and personal and household goods"
+    '96' : "Other personal service activities"
+    '97' : "Activities of households as employers of domestic personnel"
+    '98' : "Undifferentiated goods- and services-producing activities of private households for own use"
+    '99' : "Activities of extraterritorial organisations and bodies"
+sic_division_sections:
+    '01' : "A"
+    '02' : "A"
+    '03' : "A"
+    '05' : "B"
+    '06' : "B"
+    '07' : "B"
+    '08' : "B"
+    '09' : "B"
+    '10' : "C"
+    '11' : "C"
+    '12' : "C"
+    '13' : "C"
+    '14' : "C"
+    '15' : "C"
+    '16' : "C"
+    '17' : "C"
+    '18' : "C"
+    '19' : "C"
+    '20' : "C"
+    '21' : "C"
+    '22' : "C"
+    '23' : "C"
+    '24' : "C"
+    '25' : "C"
+    '26' : "C"
+    '27' : "C"
+    '28' : "C"
+    '29' : "C"
+    '30' : "C"
+    '31' : "C"
+    '32' : "C"
+    '33' : "C"
+    '35' : "D"
+    '36' : "E"
+    '37' : "E"
+    '38' : "E"
+    '39' : "E"
+    '41' : "F"
+    '42' : "F"
+    '43' : "F"
+    '45' : "G"
+    '46' : "G"
+    '47' : "G"
+    '49' : "H"
+    '50' : "H"
+    '51' : "H"
+    '52' : "H"
+    '53' : "H"
+    '55' : "I"
+    '56' : "I"
+    '58' : "J"
+    '59' : "J"
+    '60' : "J"
+    '61' : "J"
+    '62' : "J"
+    '63' : "J"
+    '64' : "K"
+    '65' : "K"
+    '66' : "K"
+    '68' : "L"
+    '69' : "M"
+    '70' : "M"
+    '71' : "M"
+    '72' : "M"
+    '73' : "M"
+    '74' : "M"
+    '75' : "M"
+    '77' : "N"
+    '78' : "N"
+    '79' : "N"
+    '80' : "N"
+    '81' : "N"
+    '82' : "N"
+    '84' : "O"
+    '85' : "P"
+    '86' : "Q"
+    '87' : "Q"
+    '88' : "Q"
+    '90' : "R"
+    '91' : "R"
+    '92' : "R"
+    '93' : "R"
+    '94' : "S"
+    '95' : "S"
+    '96' : "S"
+    '97' : "T"
+    '98' : "T"
+    '99' : "U"
+sic_groups:
+    '011' : "Growing of non-perennial crops"
+    '012' : "Growing of perennial crops"
+    '014' : "Animal production"
+    '016' : "Support activities to agriculture and post-harvest crop activities"
+    '031' : "Fishing"
+    '032' : "Aquaculture"
+    '072' : "Mining of non-ferrous metal ores"
+    '081' : "Quarrying of stone, sand and clay"
+    '089' : "Mining and quarrying not elsewhere classified"
+    '101' : "Processing and preserving of meat and production of meat products"
+    '103' : "Processing and preserving of fruit and vegetables"
+    '104' : "Manufacture of vegetable and animal oils and fats"
+    '105' : "Manufacture of dairy products"
+    '106' : "Manufacture of grain mill products, starches and starch products"
+    '107' : "Manufacture of bakery and farinaceous products"
+    '108' : "Manufacture of other food products"
+    '109' : "Manufacture of prepared animal feeds"
+    '110' : "Manufacture of beverages"
+    '139' : "Manufacture of other textiles"
+    '141' : "Manufacture of wearing apparel, except fur apparel"
+    '143' : "Manufacture of knitted and crocheted apparel"
+    '151' : "Tanning and dressing of leather; manufacture of luggage, handbags, saddlery and harness; dressing and dyeing of fur"
+    '162' : "Manufacture of products of wood, cork, straw and plaiting materials"
+    '171' : "Manufacture of pulp, paper and paperboard"
+    '172' : "Manufacture of articles of paper and paperboard"
+    '181' : "Printing and service activities related to printing"
+    '201' : "Manufacture of basic chemicals, fertilisers and nitrogen compounds, plastics and synthetic rubber in primary forms"
+    '204' : "Manufacture of soap and detergents, cleaning and polishing preparations, perfumes and toilet preparations"
+    '205' : "Manufacture of other chemical products"
+    '221' : "Manufacture of rubber products"
+    '222' : "Manufacture of plastics products"
+    '231' : "Manufacture of glass and glass products"
+    '233' : "Manufacture of clay building materials"
+    '234' : "Manufacture of other porcelain and ceramic products"
+    '235' : "Manufacture of cement, lime and plaster"
+    '236' : "Manufacture of articles of concrete, cement and plaster"
+    '239' : "Manufacture of abrasive products and non-metallic mineral products not elsewhere classified"
+    '243' : "Manufacture of other products of first processing of steel"
+    '244' : "Manufacture of basic precious and other non-ferrous metals"
+    '245' : "Casting of metals"
+    '251' : "Manufacture of structural metal products"
+    '252' : "Manufacture of tanks, reservoirs and containers of metal"
+    '256' : "Treatment and coating of metals; machining"
+    '257' : "Manufacture of cutlery, tools and general hardware"
+    '259' : "Manufacture of other fabricated metal products"
+    '261' : "Manufacture of electronic components and boards"
+    '265' : "Manufacture of instruments and appliances for measuring, testing and navigation; watches and clocks"
+    '271' : "Manufacture of electric motors, generators, transformers and electricity distribution and control apparatus"
+    '273' : "Manufacture of wiring and wiring devices"
+    '275' : "Manufacture of domestic appliances"
+    '281' : "Manufacture of general-purpose machinery"
+    '282' : "Manufacture of other general-purpose machinery"
+    '284' : "Manufacture of metal forming machinery and machine tools"
+    '289' : "Manufacture of other special-purpose machinery"
+    '293' : "Manufacture of parts and accessories for motor vehicles"
+    '301' : "Building of ships and boats"
+    '309' : "Manufacture of transport equipment not elsewhere classified"
+    '310' : "Manufacture of furniture"
+    '321' : "Manufacture of jewellery, bijouterie and related articles"
+    '329' : "Manufacturing not elsewhere classified"
+    '331' : "Repair of fabricated metal products, machinery and equipment"
+    '351' : "Electric power generation, transmission and distribution"
+    '352' : "Manufacture of gas; distribution of gaseous fuels through mains"
+    '381' : "Waste collection"
+    '382' : "Waste treatment and disposal"
+    '383' : "Materials recovery"
+    '421' : "Construction of roads and railways"
+    '422' : "Construction of utility projects"
+    '429' : "Construction of other civil engineering projects"
+    '431' : "Demolition and site preparation"
+    '432' : "Electrical, plumbing and other construction installation activities"
+    '433' : "Building completion and finishing"
+    '439' : "Other specialised construction activities"
+    '451' : "Sale of motor vehicles"
+    '453' : "Sale of motor vehicle parts and accessories"
+    '461' : "Wholesale on a fee or contract basis"
+    '462' : "Wholesale of agricultural raw materials and live animals"
+    '463' : "Wholesale of food, beverages and tobacco"
+    '464' : "Wholesale of household goods"
+    '465' : "Wholesale of information and communication equipment"
+    '466' : "Wholesale of other machinery, equipment and supplies"
+    '467' : "Other specialised wholesale"
+    '471' : "Retail sale in non-specialised stores"
+    '472' : "Retail sale of food, beverages and tobacco in specialised stores"
+    '474' : "Retail sale of information and communication equipment in specialised stores"
+    '475' : "Retail sale of other household equipment in specialised stores"
+    '476' : "Retail sale of cultural and recreation goods in specialised stores"
+    '477' : "Retail sale of other goods in specialised stores"
+    '478' : "Retail sale via stalls and markets"
+    '479' : "Retail trade not in stores, stalls or markets"
+    '493' : "Other passenger land transport"
+    '494' : "Freight transport by road and removal services"
+    '512' : "Freight air transport and space transport"
+    '522' : "Support activities for transportation"
+    '562' : "Event catering and other food service activities"
+    '581' : "Publishing of books, periodicals and other publishing activities"
+    '582' : "Software publishing"
+    '591' : "Motion picture, video and television programme activities"
+    '620' : "Computer programming, consultancy and related activities"
+    '631' : "Data processing, hosting and related activities; web portals"
+    '639' : "Other information service activities"
+    '641' : "Monetary intermediation"
+    '649' : "Other financial service activities, except insurance and pension funding"
+    '651' : "Insurance"
+    '661' : "Activities auxiliary to financial services, except insurance and pension funding"
+    '662' : "Activities auxiliary to insurance and pension funding"
+    '683' : "Real estate activities on a fee or contract basis"
+    '702' : "Management consultancy activities"
+    '711' : "Architectural and engineering activities and related technical consultancy"
+    '721' : "Research and experimental development on natural sciences and engineering"
+    '731' : "Advertising"
+    '749' : "Other professional, scientific and technical activities not elsewhere classified"
+    '771' : "Renting and leasing of motor vehicles"
+    '772' : "Renting and leasing of personal and household goods"
+    '773' : "Renting and leasing of other machinery, equipment and tangible goods"
+    '791' : "Travel agency and tour operator activities"
+    '812' : "Cleaning activities"
+    '821' : "Office administrative and support activities"
+    '829' : "Business support service activities not elsewhere classified"
+    '841' : "Administration of the State and the economic and social policy of the community"
+    '842' : "Provision of services to the community as a whole"
+    '853' : "Secondary education"
+    '854' : "Higher education"
+    '855' : "Other education"
+    '862' : "Medical and dental practice activities"
+    '889' : "Other social work activities without accommodation"
+    '900' : "Creative, arts and entertainment activities"
+    '910' : "Libraries, archives, museums and other cultural activities"
+    '931' : "Sports activities"
+    '932' : "Amusement and recreation activities"
+    '941' : "Activities of business, employers and professional membership organisations"
+    '949' : "Activities of other membership organisations"
+    '951' : "Repair of computers and communication equipment"
+    '952' : "Repair of personal and household goods"
+    '960' : "Other personal service activities"
+sic_classes:
+    '0162' : "Support activities for animal production"
+    '0510' : "Mining of hard coal"
+    '1051' : "Operation of dairies and cheese making"
+    '1061' : "Manufacture of grain mill products"
+    '1082' : "Manufacture of cocoa, chocolate and sugar confectionery"
+    '1083' : "Processing of tea and coffee"
+    '1392' : "Manufacture of made-up textile articles, except apparel"
+    '1393' : "Manufacture of carpets and rugs"
+    '1413' : "Manufacture of other outerwear"
+    '1414' : "Manufacture of underwear"
+    '1721' : "Manufacture of corrugated paper and paperboard and of containers of paper and paperboard"
+    '1812' : "Other printing"
+    '1820' : "Reproduction of recorded media"
+    '1920' : "Manufacture of refined petroleum products"
+    '2030' : "Manufacture of paints, varnishes and similar coatings, printing ink and mastics"
+    '2041' : "Manufacture of soap and detergents, cleaning and polishing preparations"
+    '2630' : "Manufacture of communication equipment"
+    '2651' : "Manufacture of instruments and appliances for measuring, testing and navigation"
+    '2670' : "Manufacture of optical instruments and photographic equipment"
+    '2813' : "Manufacture of other pumps and compressors"
+    '2830' : "Manufacture of agricultural and forestry machinery"
+    '2892' : "Manufacture of machinery for mining, quarrying and construction"
+    '2920' : "Manufacture of bodies (coachwork) for motor vehicles; manufacture of trailers and semi-trailers"
+    '3240' : "Manufacture of games and toys"
+    '4120' : "Construction of residential and non-residential buildings"
+    '4334' : "Painting and glazing"
+    '4399' : "Other specialised construction activities not elsewhere classified"
+    '4511' : "Sale of cars and light motor vehicles"
+    '4634' : "Wholesale of beverages"
+    '4643' : "Wholesale of electrical household appliances"
+    '4649' : "Wholesale of other household goods"
+    '4671' : "Wholesale of solid, liquid and gaseous fuels and related products"
+    '4742' : "Retail sale of telecommunications equipment in specialised stores"
+    '4759' : "Retail sale of furniture, lighting equipment and other household articles in specialised stores"
+    '4772' : "Retail sale of footwear and leather goods in specialised stores"
+    '4774' : "Retail sale of medical and orthopaedic goods in specialised stores"
+    '4778' : "Other retail sale of new goods in specialised stores"
+    '4779' : "Retail sale of second-hand goods in stores"
+    '4931' : "Urban and suburban passenger land transport"
+    '5110' : "Passenger air transport"
+    '5210' : "Warehousing and storage"
+    '5221' : "Service activities incidental to land transportation"
+    '5224' : "Cargo handling"
+    '5320' : "Other postal and courier activities"
+    '5520' : "Holiday and other short-stay accommodation"
+    '5610' : "Restaurants and mobile food service activities"
+    '5630' : "Beverage serving activities"
+    '5814' : "Publishing of journals and periodicals"
+    '5911' : "Motion picture, video and television programme production activities"
+    '5913' : "Motion picture, video and television programme distribution activities"
+    '6201' : "Computer programming activities"
+    '6419' : "Other monetary intermediation"
+    '6420' : "Activities of holding companies"
+    '6430' : "Trusts, funds and similar financial entities"
+    '6492' : "Other credit granting"
+    '6499' : "Other financial service activities, except insurance and pension funding, not elsewhere classified"
+    '6520' : "Reinsurance"
+    '6820' : "Renting and operating of own or leased real estate"
+    '6910' : "Legal activities"
+    '6920' : "Accounting, bookkeeping and auditing activities; tax consultancy"
+    '7022' : "Business and other management consultancy activities"
+    '7111' : "Architectural activities"
+    '7112' : "Engineering activities and related technical consultancy"
+    '7420' : "Photographic activities"
+    '7490' : "Other professional, scientific and technical activities not elsewhere classified"
+    '7729' : "Renting and leasing of other personal and household goods"
+    '7734' : "Renting and leasing of water transport equipment"
+    '7735' : "Renting and leasing of air transport equipment"
+    '7810' : "Activities of employment placement agencies"
+    '7990' : "Other reservation service and related activities"
+    '8122' : "Other building and industrial cleaning activities"
+    '8129' : "Other cleaning activities"
+    '8230' : "Organisation of conventions and trade shows"
+    '8291' : "Activities of collection agencies and credit bureaus"
+    '8542' : "Tertiary education"
+    '8610' : "Hospital activities"
+    '9101' : "Library and archive activities"
+    '9319' : "Other sports activities"`
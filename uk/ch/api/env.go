@@ -0,0 +1,158 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Environment contains the base URLs of the Companies House services
+type Environment struct {
+	Name      string
+	URL       string // REST API
+	Document  string // Document API, empty if not available
+	Streaming string // Streaming API, empty if not available
+	TestData  string // Test data generator, empty if not available
+}
+
+// Environments of Companies House
+var (
+	// Live is the current production environment
+	Live = Environment{
+		Name:      "live",
+		URL:       "https://api.company-information.service.gov.uk",
+		Document:  "https://document-api.company-information.service.gov.uk",
+		Streaming: "https://stream.companieshouse.gov.uk",
+	}
+
+	// Sandbox is the test environment of the REST API, which only contains test data.
+	// It has no document or streaming API.
+	Sandbox = Environment{
+		Name:     "sandbox",
+		URL:      "https://api-sandbox.company-information.service.gov.uk",
+		TestData: "https://test-data-sandbox.company-information.service.gov.uk",
+	}
+
+	// Legacy is the production environment on its former hosts
+	Legacy = Environment{
+		Name:      "legacy",
+		URL:       "https://api.companieshouse.gov.uk",
+		Document:  "https://document-api.companieshouse.gov.uk",
+		Streaming: "https://stream.companieshouse.gov.uk",
+	}
+)
+
+// apiHosts are the hosts of the production REST API, which are resolved to the REST API of the environment
+var apiHosts = []string{
+	"api.company-information.service.gov.uk",
+	"api.companieshouse.gov.uk",
+}
+
+// documentHosts are the hosts used in document metadata links, which are resolved to the document API of the environment
+var documentHosts = []string{
+	"document-api.company-information.service.gov.uk",
+	"frontend-doc-api.company-information.service.gov.uk",
+	"document-api.companieshouse.gov.uk",
+	"frontend-doc-api.companieshouse.gov.uk",
+}
+
+// EnvironmentByName returns the environment with the provided name, e.g. live or sandbox
+func EnvironmentByName(name string) (Environment, bool) {
+	for _, env := range []Environment{Live, Sandbox, Legacy} {
+		if strings.EqualFold(env.Name, name) {
+			return env, true
+		}
+	}
+	return Environment{}, false
+}
+
+// CustomEnvironment returns an environment which serves all APIs from a single base URL,
+// e.g. a proxy or a mock server
+func CustomEnvironment(baseURL string) Environment {
+	return Environment{Name: "custom", URL: baseURL, Document: baseURL, Streaming: baseURL, TestData: baseURL}
+}
+
+// ClientOption is a type used for passing options to New
+type ClientOption func(*API) error
+
+// WithEnvironment makes the API use the URLs of the environment
+func WithEnvironment(env Environment) ClientOption {
+	return func(a *API) error {
+		return a.SetEnvironment(env)
+	}
+}
+
+// WithBaseURL makes the API use a single base URL for all APIs, see CustomEnvironment
+func WithBaseURL(baseURL string) ClientOption {
+	return WithEnvironment(CustomEnvironment(baseURL))
+}
+
+// SetEnvironment replaces the URLs of the API with those of the environment
+func (a *API) SetEnvironment(env Environment) error {
+	urls := []struct {
+		raw string
+		u   **url.URL
+	}{
+		{env.URL, &a.URL},
+		{env.Document, &a.DocumentURL},
+		{env.Streaming, &a.StreamingURL},
+		{env.TestData, &a.TestDataURL},
+	}
+
+	for _, u := range urls {
+		if u.raw == "" {
+			*u.u = nil
+			continue
+		}
+
+		parsed, err := url.Parse(u.raw)
+		if err != nil {
+			return errors.Wrapf(err, "parsing URL of %s environment", env.Name)
+		}
+		*u.u = parsed
+	}
+
+	if a.URL == nil {
+		return fmt.Errorf("no API URL in %s environment", env.Name)
+	}
+	return nil
+}
+
+// ResolveLink returns the URL of a link in a response. Relative links and absolute links to the production
+// REST API resolve against the REST API, and links to the document API are resolved against the document API
+// of the environment. Other links are returned unchanged.
+func (a *API) ResolveLink(link string) (*url.URL, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing link")
+	}
+
+	base := a.URL
+	if u.IsAbs() && !hasHost(apiHosts, u.Host) {
+		if !hasHost(documentHosts, u.Host) {
+			return u, nil
+		}
+		if a.DocumentURL == nil {
+			return nil, fmt.Errorf("no document API in environment")
+		}
+		base = a.DocumentURL
+	}
+
+	res := *base
+	res.Path = strings.TrimSuffix(base.Path, "/") + u.Path
+	res.RawPath = ""
+	res.RawQuery = u.RawQuery
+	res.Fragment = ""
+	return &res, nil
+}
+
+func hasHost(hosts []string, host string) bool {
+	for _, h := range hosts {
+		if strings.EqualFold(host, h) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/appinesshq/globire-go/uk/ch/api/enum"
+)
+
+// FilingDescription represents the description key of a filing
+type FilingDescription string
+
+// String implements the Stringer interface to get a human readable string from the CH enums.
+// The result is the description template, which may contain markup and placeholders.
+func (f FilingDescription) String() string {
+	return enum.FilingHistoryDescriptions.Get("description", string(f))
+}
+
+// Validate returns an error if the value isn't a known code
+func (f FilingDescription) Validate() error {
+	return validate(enum.FilingHistoryDescriptions, "description", string(f))
+}
+
+type (
+	// Filing contains the data of a single item of a company's filing history
+	Filing struct {
+		ActionDate        ChDate                 `json:"action_date,omitzero"`
+		Barcode           string                 `json:"barcode"`
+		Category          string                 `json:"category"`
+		Subcategory       string                 `json:"subcategory"`
+		Date              ChDate                 `json:"date,omitzero"`
+		Description       FilingDescription      `json:"description"`
+		DescriptionValues map[string]interface{} `json:"description_values"`
+		Links             FilingLinks            `json:"links"`
+		Pages             int                    `json:"pages"`
+		PaperFiled        bool                   `json:"paper_filed"`
+		TransactionID     string                 `json:"transaction_id"`
+		Type              string                 `json:"type"`
+	}
+
+	// FilingHistory contains the server response of a request for the filing history of a company
+	FilingHistory struct {
+		Etag                string   `json:"etag"`
+		FilingHistoryStatus string   `json:"filing_history_status"`
+		Items               []Filing `json:"items"`
+		ItemsPerPage        int      `json:"items_per_page"`
+		Kind                string   `json:"kind"`
+		Start               int      `json:"start_index"`
+		TotalCount          int      `json:"total_count"`
+	}
+
+	// DocumentMetadata contains the metadata of the document of a filing, from the document API
+	DocumentMetadata struct {
+		CompanyNumber       string                `json:"company_number"`
+		Barcode             string                `json:"barcode"`
+		Category            string                `json:"category"`
+		Pages               int                   `json:"pages"`
+		SignificantDate     string                `json:"significant_date"`
+		SignificantDateType string                `json:"significant_date_type"`
+		Etag                string                `json:"etag"`
+		Links               DocumentMetadataLinks `json:"links"`
+		Resources           map[string]struct {
+			ContentLength int `json:"content_length"`
+		} `json:"resources"` // Content type -> available format of the document
+	}
+)
+
+// FilingHistory gets and returns a company's filing history
+// Possible options: ItemsPerPage, StartIndex, Category
+func (c *Company) FilingHistory(options ...Option) (*FilingHistory, error) {
+	res := FilingHistory{}
+	params := url.Values{}
+	for _, option := range options {
+		option(&params)
+	}
+
+	path := fmt.Sprintf("/company/%s/filing-history", c.CompanyNumber)
+	if err := c.api.get(c.CompanyNumber, FilingHistoryResource, path, params, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
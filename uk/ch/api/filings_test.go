@@ -0,0 +1,44 @@
+package api_test
+
+import (
+	"net/url"
+	"testing"
+
+	ch "github.com/appinesshq/globire-go/uk/ch/api"
+	"github.com/appinesshq/globire-go/uk/ch/api/tests"
+)
+
+func TestGetFilingHistory(t *testing.T) {
+	api, err := ch.New("12345")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	ts := tests.NewMockServer()
+	api.URL, err = url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	c, err := api.GetCompany("12345678")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	fh, err := c.FilingHistory(ch.Category("confirmation-statement", "incorporation"))
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	if got, expected := fh.Items[0].Type, "CS01"; got != expected {
+		t.Fatalf("expected %q, but got %q", expected, got)
+	}
+
+	if got, expected := fh.Items[0].DescriptionValues["made_up_date"], "2020-06-24"; got != expected {
+		t.Fatalf("expected %q, but got %q", expected, got)
+	}
+
+	if got, expected := fh.Items[1].Description.String(), "**Incorporation**"; got != expected {
+		t.Fatalf("expected %q, but got %q", expected, got)
+	}
+}
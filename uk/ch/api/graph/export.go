@@ -0,0 +1,160 @@
+package graph
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WriteDOT writes the graph in the Graphviz DOT language
+func (g *Graph) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph structure {")
+	fmt.Fprintln(bw, "\trankdir=BT;")
+
+	for _, n := range g.Nodes {
+		shape := "ellipse"
+		switch n.Kind {
+		case CompanyNode:
+			shape = "box"
+		case EntityNode:
+			shape = "hexagon"
+		}
+
+		label := n.Label
+		if n.CompanyNumber != "" {
+			label += "\n" + n.CompanyNumber
+		}
+		fmt.Fprintf(bw, "\t%s [label=%s, shape=%s];\n", strconv.Quote(n.ID), strconv.Quote(label), shape)
+	}
+
+	for _, e := range g.Edges {
+		label := e.Role
+		if e.Kind == PSCEdge {
+			label = "psc"
+			if len(e.NaturesOfControl) > 0 {
+				label = string(e.NaturesOfControl[0])
+			}
+		}
+
+		style := "solid"
+		if !e.End.IsZero() {
+			style = "dashed"
+		}
+		fmt.Fprintf(bw, "\t%s -> %s [label=%s, style=%s];\n", strconv.Quote(e.From), strconv.Quote(e.To), strconv.Quote(label), style)
+	}
+
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+type (
+	graphML struct {
+		XMLName xml.Name       `xml:"graphml"`
+		XMLNS   string         `xml:"xmlns,attr"`
+		Keys    []graphMLKey   `xml:"key"`
+		Graph   graphMLContent `xml:"graph"`
+	}
+
+	graphMLKey struct {
+		ID       string `xml:"id,attr"`
+		For      string `xml:"for,attr"`
+		AttrName string `xml:"attr.name,attr"`
+		AttrType string `xml:"attr.type,attr"`
+	}
+
+	graphMLContent struct {
+		ID          string        `xml:"id,attr"`
+		EdgeDefault string        `xml:"edgedefault,attr"`
+		Nodes       []graphMLNode `xml:"node"`
+		Edges       []graphMLEdge `xml:"edge"`
+	}
+
+	graphMLNode struct {
+		ID   string        `xml:"id,attr"`
+		Data []graphMLData `xml:"data"`
+	}
+
+	graphMLEdge struct {
+		Source string        `xml:"source,attr"`
+		Target string        `xml:"target,attr"`
+		Data   []graphMLData `xml:"data"`
+	}
+
+	graphMLData struct {
+		Key   string `xml:"key,attr"`
+		Value string `xml:",chardata"`
+	}
+)
+
+// WriteGraphML writes the graph in the GraphML format
+func (g *Graph) WriteGraphML(w io.Writer) error {
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "kind", For: "node", AttrName: "kind", AttrType: "string"},
+			{ID: "label", For: "node", AttrName: "label", AttrType: "string"},
+			{ID: "company_number", For: "node", AttrName: "company_number", AttrType: "string"},
+			{ID: "company_status", For: "node", AttrName: "company_status", AttrType: "string"},
+			{ID: "depth", For: "node", AttrName: "depth", AttrType: "int"},
+			{ID: "relation", For: "edge", AttrName: "relation", AttrType: "string"},
+			{ID: "role", For: "edge", AttrName: "role", AttrType: "string"},
+			{ID: "start", For: "edge", AttrName: "start", AttrType: "string"},
+			{ID: "end", For: "edge", AttrName: "end", AttrType: "string"},
+			{ID: "natures_of_control", For: "edge", AttrName: "natures_of_control", AttrType: "string"},
+		},
+		Graph: graphMLContent{ID: "structure", EdgeDefault: "directed"},
+	}
+
+	for _, n := range g.Nodes {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			ID: n.ID,
+			Data: nonEmpty(
+				graphMLData{Key: "kind", Value: string(n.Kind)},
+				graphMLData{Key: "label", Value: n.Label},
+				graphMLData{Key: "company_number", Value: n.CompanyNumber},
+				graphMLData{Key: "company_status", Value: string(n.CompanyStatus)},
+				graphMLData{Key: "depth", Value: strconv.Itoa(n.Depth)},
+			),
+		})
+	}
+
+	for _, e := range g.Edges {
+		noc := make([]string, len(e.NaturesOfControl))
+		for i, n := range e.NaturesOfControl {
+			noc[i] = string(n)
+		}
+
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			Source: e.From,
+			Target: e.To,
+			Data: nonEmpty(
+				graphMLData{Key: "relation", Value: string(e.Kind)},
+				graphMLData{Key: "role", Value: e.Role},
+				graphMLData{Key: "start", Value: formatDate(e.Start)},
+				graphMLData{Key: "end", Value: formatDate(e.End)},
+				graphMLData{Key: "natures_of_control", Value: strings.Join(noc, ",")},
+			),
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(doc)
+}
+
+func nonEmpty(data ...graphMLData) []graphMLData {
+	var res []graphMLData
+	for _, d := range data {
+		if d.Value != "" {
+			res = append(res, d)
+		}
+	}
+	return res
+}
@@ -0,0 +1,342 @@
+// Package graph builds the corporate structure around a company, by expanding through its
+// officers, persons with significant control and the appointments of its officers.
+package graph
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/appinesshq/globire-go/uk/ch/api"
+	"github.com/pkg/errors"
+)
+
+// NodeKind represents the kind of a node in the graph
+type NodeKind string
+
+const (
+	// CompanyNode is a company registered at Companies House
+	CompanyNode NodeKind = "company"
+
+	// PersonNode is a natural person, acting as officer or PSC
+	PersonNode NodeKind = "person"
+
+	// EntityNode is a legal entity which isn't registered at Companies House, e.g. a foreign company
+	EntityNode NodeKind = "entity"
+)
+
+// Node represents a company, person or entity in the graph
+type Node struct {
+	ID            string
+	Kind          NodeKind
+	Label         string
+	CompanyNumber string            // Set for company nodes
+	CompanyStatus api.CompanyStatus // Set for company nodes which have been retrieved
+	Depth         int               // Number of companies between the start company and this node
+}
+
+// EdgeKind represents the relation an edge describes
+type EdgeKind string
+
+const (
+	// OfficerEdge links an officer to the company it's appointed to
+	OfficerEdge EdgeKind = "officer"
+
+	// PSCEdge links a person with significant control to the company it controls
+	PSCEdge EdgeKind = "psc"
+)
+
+// Edge represents a relation from a person or entity to a company
+type Edge struct {
+	From             string
+	To               string
+	Kind             EdgeKind
+	Role             string // Officer role, or PSC kind
+	Start            time.Time
+	End              time.Time
+	NaturesOfControl []api.NatureOfControl
+}
+
+// Graph contains the nodes and edges of a corporate structure
+type Graph struct {
+	Nodes []*Node
+	Edges []Edge
+	index map[string]*Node
+	edges map[string]bool
+}
+
+// New returns an empty graph
+func New() *Graph {
+	return &Graph{index: make(map[string]*Node), edges: make(map[string]bool)}
+}
+
+// Node returns the node with the provided ID, or nil if it doesn't exist
+func (g *Graph) Node(id string) *Node {
+	return g.index[id]
+}
+
+// AddNode adds a node to the graph, or returns the existing node with the same ID
+func (g *Graph) AddNode(n Node) *Node {
+	if e, ok := g.index[n.ID]; ok {
+		if e.Depth > n.Depth {
+			e.Depth = n.Depth
+		}
+		if e.Label == "" {
+			e.Label = n.Label
+		}
+		return e
+	}
+	g.Nodes = append(g.Nodes, &n)
+	g.index[n.ID] = &n
+	return &n
+}
+
+// AddEdge adds an edge to the graph, unless an identical relation already exists
+func (g *Graph) AddEdge(e Edge) {
+	key := strings.Join([]string{e.From, e.To, string(e.Kind), e.Role, e.Start.Format("2006-01-02")}, "|")
+	if g.edges[key] {
+		return
+	}
+	g.edges[key] = true
+	g.Edges = append(g.Edges, e)
+}
+
+// CompanyID returns the node ID of a company
+func CompanyID(companyNumber string) string {
+	return "company:" + companyNumber
+}
+
+// Builder expands the corporate structure around a company
+type Builder struct {
+	API *api.API
+
+	// Depth is the maximum number of hops from the start company to companies which are expanded
+	Depth int
+
+	// MaxNodes is the maximum number of nodes in the graph; further nodes and their edges are left out.
+	// Zero means no limit.
+	MaxNodes int
+
+	Officers     bool // Expand through the officers of companies
+	PSCs         bool // Expand through the persons with significant control of companies
+	Appointments bool // Expand through the other appointments of officers
+	Resigned     bool // Include resigned officers and ceased PSCs
+}
+
+// NewBuilder returns a Builder which expands through officers, PSCs and appointments up to the provided depth
+func NewBuilder(a *api.API, depth int) *Builder {
+	return &Builder{API: a, Depth: depth, Officers: true, PSCs: true, Appointments: true}
+}
+
+// Build returns the graph around the provided company
+func (b *Builder) Build(ctx context.Context, companyNumber string) (*Graph, error) {
+	g := New()
+	g.AddNode(Node{ID: CompanyID(companyNumber), Kind: CompanyNode, CompanyNumber: companyNumber})
+
+	queue := []string{companyNumber}
+	visited := map[string]bool{companyNumber: true}
+	expanded := map[string]bool{} // Officers whose appointments have been added
+
+	enqueue := func(number string, depth int) {
+		if !visited[number] && depth <= b.Depth {
+			visited[number] = true
+			queue = append(queue, number)
+		}
+	}
+
+	for len(queue) > 0 {
+		if err := ctx.Err(); err != nil {
+			return g, err
+		}
+		number := queue[0]
+		queue = queue[1:]
+		node := g.Node(CompanyID(number))
+		depth := node.Depth
+
+		c, err := b.API.GetCompany(number)
+		if err != nil {
+			if depth == 0 {
+				return nil, err
+			}
+			// Keep the node for companies which can't be retrieved, e.g. dissolved long ago
+			continue
+		}
+		node.Label = c.Name
+		node.CompanyStatus = c.CompanyStatus
+
+		if b.Officers {
+			officers, err := allOfficers(c)
+			if err != nil {
+				return g, errors.Wrapf(err, "getting officers of %s", number)
+			}
+
+			for _, o := range officers {
+				if !b.Resigned && !o.ResignedOn.IsZero() {
+					continue
+				}
+
+				from := b.officerNode(g, o, depth)
+				if from == nil {
+					continue
+				}
+				g.AddEdge(Edge{From: from.ID, To: node.ID, Kind: OfficerEdge, Role: string(o.OfficerRole), Start: o.AppointedOn.Time, End: o.ResignedOn.Time})
+				if from.Kind == CompanyNode {
+					enqueue(from.CompanyNumber, depth+1)
+				}
+
+				id := o.ID()
+				if !b.Appointments || id == "" || expanded[id] || depth+1 > b.Depth {
+					continue
+				}
+				expanded[id] = true
+
+				if err := b.appointments(ctx, g, from, id, depth, enqueue); err != nil {
+					return g, err
+				}
+			}
+		}
+
+		if b.PSCs {
+			pscs, err := allPSCs(c)
+			if err != nil {
+				return g, errors.Wrapf(err, "getting persons with significant control of %s", number)
+			}
+
+			for _, p := range pscs {
+				if !b.Resigned && (p.Ceased || !p.CeasedOn.IsZero()) {
+					continue
+				}
+
+				from := b.pscNode(g, p, depth)
+				if from == nil {
+					continue
+				}
+				g.AddEdge(Edge{From: from.ID, To: node.ID, Kind: PSCEdge, Role: string(p.Kind), Start: p.NotifiedOn.Time, End: p.CeasedOn.Time, NaturesOfControl: p.NaturesOfControl})
+				if from.Kind == CompanyNode {
+					enqueue(from.CompanyNumber, depth+1)
+				}
+			}
+		}
+	}
+
+	return g, nil
+}
+
+// appointments adds the other appointments of an officer to the graph
+func (b *Builder) appointments(ctx context.Context, g *Graph, from *Node, id string, depth int, enqueue func(string, int)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var appointments []api.Appointment
+	for {
+		res, err := b.API.OfficerAppointments(id, api.ItemsPerPage(50), api.StartIndex(len(appointments)))
+		if err != nil {
+			return errors.Wrapf(err, "getting appointments of %s", id)
+		}
+		appointments = append(appointments, res.Items...)
+		if len(res.Items) == 0 || len(appointments) >= res.TotalResults {
+			break
+		}
+	}
+
+	for _, a := range appointments {
+		if !b.Resigned && !a.ResignedOn.IsZero() {
+			continue
+		}
+
+		number := a.AppointedTo.CompanyNumber
+		to := b.addNode(g, Node{ID: CompanyID(number), Kind: CompanyNode, Label: a.AppointedTo.CompanyName, CompanyNumber: number, CompanyStatus: a.AppointedTo.CompanyStatus, Depth: depth + 1})
+		if to == nil {
+			continue
+		}
+		g.AddEdge(Edge{From: from.ID, To: to.ID, Kind: OfficerEdge, Role: string(a.OfficerRole), Start: a.AppointedOn.Time, End: a.ResignedOn.Time})
+		enqueue(number, depth+1)
+	}
+
+	return nil
+}
+
+// addNode adds a node to the graph, or returns the existing node with the same ID.
+// It returns nil if the node is new and the graph already contains MaxNodes nodes.
+func (b *Builder) addNode(g *Graph, n Node) *Node {
+	if b.MaxNodes > 0 && len(g.Nodes) >= b.MaxNodes && g.Node(n.ID) == nil {
+		return nil
+	}
+	return g.AddNode(n)
+}
+
+// officerNode adds the node of an officer. Corporate officers registered at Companies House
+// are represented by their company node. It returns nil if the graph is full.
+func (b *Builder) officerNode(g *Graph, o api.Officer, depth int) *Node {
+	if number, ok := o.Identification.UKCompanyNumber(); ok {
+		return b.addNode(g, Node{ID: CompanyID(number), Kind: CompanyNode, Label: o.Name, CompanyNumber: number, Depth: depth + 1})
+	}
+
+	kind := PersonNode
+	if o.Identification.IdentificationType != "" || o.Identification.RegistrationNumber != "" {
+		kind = EntityNode
+	}
+
+	id := o.ID()
+	if id == "" {
+		id = strings.ToLower(o.Name)
+	}
+	return b.addNode(g, Node{ID: "officer:" + id, Kind: kind, Label: o.Name, Depth: depth})
+}
+
+// pscNode adds the node of a person with significant control. Corporate PSCs registered at
+// Companies House are represented by their company node. It returns nil if the graph is full.
+func (b *Builder) pscNode(g *Graph, p api.PSC, depth int) *Node {
+	if number, ok := p.Identification.UKCompanyNumber(); ok && p.IsCorporate() {
+		return b.addNode(g, Node{ID: CompanyID(number), Kind: CompanyNode, Label: p.Name, CompanyNumber: number, Depth: depth + 1})
+	}
+
+	kind := PersonNode
+	if p.IsCorporate() {
+		kind = EntityNode
+	}
+
+	id := p.Links.Self
+	if id == "" {
+		id = strings.ToLower(p.Name)
+	}
+	return b.addNode(g, Node{ID: "psc:" + id, Kind: kind, Label: p.Name, Depth: depth})
+}
+
+// allOfficers gets all pages of the officers of a company
+func allOfficers(c *api.Company) ([]api.Officer, error) {
+	var officers []api.Officer
+	for {
+		res, err := c.Officers(api.ItemsPerPage(100), api.StartIndex(len(officers)))
+		if err != nil {
+			return nil, err
+		}
+		officers = append(officers, res.Items...)
+		if len(res.Items) == 0 || len(officers) >= res.TotalResults {
+			return officers, nil
+		}
+	}
+}
+
+// allPSCs gets all pages of the persons with significant control of a company
+func allPSCs(c *api.Company) ([]api.PSC, error) {
+	var pscs []api.PSC
+	for {
+		res, err := c.PersonsWithSignificantControl(api.ItemsPerPage(100), api.StartIndex(len(pscs)))
+		if err != nil {
+			return nil, err
+		}
+		pscs = append(pscs, res.Items...)
+		if len(res.Items) == 0 || len(pscs) >= res.TotalResults {
+			return pscs, nil
+		}
+	}
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,211 @@
+package graph_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	ch "github.com/appinesshq/globire-go/uk/ch/api"
+	"github.com/appinesshq/globire-go/uk/ch/api/graph"
+	"github.com/appinesshq/globire-go/uk/ch/api/tests"
+)
+
+func build(t *testing.T, number string, depth int) *graph.Graph {
+	api, err := ch.New("12345")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	ts := tests.NewMockServer()
+	t.Cleanup(ts.Close)
+	api.URL, err = url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	g, err := graph.NewBuilder(api, depth).Build(context.Background(), number)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	return g
+}
+
+func TestBuild(t *testing.T) {
+	g := build(t, "12345678", 1)
+
+	parent := g.Node(graph.CompanyID("87654321"))
+	if parent == nil {
+		t.Fatalf("expected the other appointment of the officer to be added as a company")
+	}
+
+	if got, expected := parent.Label, "PARENT LTD"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if got, expected := parent.Depth, 1; got != expected {
+		t.Errorf("expected depth %d, but got %d", expected, got)
+	}
+
+	officer := g.Node("officer:e4-ScyHpxNNUh6ZyV9wnqZS1kfY")
+	if officer == nil {
+		t.Fatalf("expected the officer to be added")
+	}
+
+	// The officer is a director of both companies, which must be a single node
+	directorships := 0
+	for _, e := range g.Edges {
+		if e.From == officer.ID && e.Kind == graph.OfficerEdge {
+			directorships++
+		}
+	}
+	if got, expected := directorships, 2; got != expected {
+		t.Errorf("expected %d directorships, but got %d", expected, got)
+	}
+}
+
+func TestBuildDepth(t *testing.T) {
+	g := build(t, "23456789", 0)
+
+	parent := g.Node(graph.CompanyID("87654321"))
+	if parent == nil {
+		t.Fatalf("expected the corporate PSC to be added as a company")
+	}
+	if got, expected := parent.Label, "PARENT LTD"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	// The parent company isn't expanded, so its PSC isn't part of the graph
+	for _, n := range g.Nodes {
+		if strings.HasPrefix(n.ID, "psc:/company/87654321") {
+			t.Errorf("expected %q not to be expanded", n.ID)
+		}
+	}
+}
+
+func TestBuildMaxNodes(t *testing.T) {
+	api, err := ch.New("12345")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	ts := tests.NewMockServer()
+	defer ts.Close()
+	api.URL, err = url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	for _, max := range []int{1, 2, 3} {
+		b := graph.NewBuilder(api, 2)
+		b.MaxNodes = max
+		g, err := b.Build(context.Background(), "12345678")
+		if err != nil {
+			t.Fatalf("expected to pass, but got: %v", err)
+		}
+		if got := len(g.Nodes); got > max {
+			t.Errorf("expected at most %d nodes, but got %d", max, got)
+		}
+		for _, e := range g.Edges {
+			if g.Node(e.From) == nil || g.Node(e.To) == nil {
+				t.Errorf("expected the nodes of edge %s -> %s", e.From, e.To)
+			}
+		}
+	}
+}
+
+func TestBuildPages(t *testing.T) {
+	// Lists are returned one item per page, regardless of the requested page size
+	lists := map[string][]map[string]interface{}{
+		"/company/00000001/officers": {
+			{"name": "ONE, Officer", "links": map[string]interface{}{"officer": map[string]string{"appointments": "/officers/ID1/appointments"}}},
+			{"name": "TWO, Officer", "links": map[string]interface{}{"officer": map[string]string{"appointments": "/officers/ID2/appointments"}}},
+			{"name": "THREE, Officer", "links": map[string]interface{}{"officer": map[string]string{"appointments": "/officers/ID3/appointments"}}},
+		},
+		"/company/00000001/persons-with-significant-control": {
+			{"kind": "individual-person-with-significant-control", "name": "Mr One", "links": map[string]string{"self": "/company/00000001/persons-with-significant-control/individual/1"}},
+			{"kind": "individual-person-with-significant-control", "name": "Mr Two", "links": map[string]string{"self": "/company/00000001/persons-with-significant-control/individual/2"}},
+		},
+		"/officers/ID1/appointments": {
+			{"appointed_to": map[string]string{"company_number": "00000002", "company_name": "TWO LTD"}, "officer_role": "director"},
+			{"appointed_to": map[string]string{"company_number": "00000003", "company_name": "THREE LTD"}, "officer_role": "director"},
+		},
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if len(parts) == 2 && parts[0] == "company" {
+			json.NewEncoder(w).Encode(map[string]string{"company_number": parts[1], "company_name": parts[1]})
+			return
+		}
+
+		items := lists[r.URL.Path]
+		start, _ := strconv.Atoi(r.URL.Query().Get("start_index"))
+		page := []map[string]interface{}{}
+		if start < len(items) {
+			page = items[start : start+1]
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"total_results": len(items), "start_index": start, "items": page})
+	}))
+	defer ts.Close()
+
+	api, err := ch.New("12345")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	api.URL, err = url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	g, err := graph.NewBuilder(api, 1).Build(context.Background(), "00000001")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	for _, id := range []string{
+		"officer:ID1", "officer:ID2", "officer:ID3",
+		"psc:/company/00000001/persons-with-significant-control/individual/1",
+		"psc:/company/00000001/persons-with-significant-control/individual/2",
+		graph.CompanyID("00000002"), graph.CompanyID("00000003"),
+	} {
+		if g.Node(id) == nil {
+			t.Errorf("expected node %q", id)
+		}
+	}
+}
+
+func TestExport(t *testing.T) {
+	g := build(t, "23456789", 1)
+
+	var dot bytes.Buffer
+	if err := g.WriteDOT(&dot); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if !strings.Contains(dot.String(), `"company:87654321" -> "company:23456789"`) {
+		t.Errorf("expected the ownership edge in:\n%s", dot.String())
+	}
+
+	var gml bytes.Buffer
+	if err := g.WriteGraphML(&gml); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	var doc struct {
+		Nodes []struct {
+			ID string `xml:"id,attr"`
+		} `xml:"graph>node"`
+	}
+	if err := xml.Unmarshal(gml.Bytes(), &doc); err != nil {
+		t.Fatalf("expected valid XML, but got: %v", err)
+	}
+	if got, expected := len(doc.Nodes), len(g.Nodes); got != expected {
+		t.Errorf("expected %d nodes, but got %d", expected, got)
+	}
+}
@@ -0,0 +1,471 @@
+// Package identity clusters officer records which are likely to be the same person, even if
+// Companies House lists them under different officer IDs, and explains every match.
+package identity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/appinesshq/globire-go/uk/ch/api"
+)
+
+// DefaultThreshold is the default minimum score of a match
+const DefaultThreshold = 0.7
+
+// MaxScoreWithoutBirthDate is the highest score of people whose dates of birth can't be compared.
+// It's below DefaultThreshold, because a shared name and service address are common.
+const MaxScoreWithoutBirthDate = 0.6
+
+// Weights of the elements which are compared
+const (
+	surnameWeight     = 0.2
+	forenameWeight    = 0.25
+	initialWeight     = 0.1
+	middleNameWeight  = 0.05
+	birthDateWeight   = 0.3
+	nationalityWeight = 0.05
+	residenceWeight   = 0.05
+	postcodeWeight    = 0.15
+	addressWeight     = 0.1
+)
+
+// Reason explains a part of the score of a match
+type Reason struct {
+	Element string  // e.g. surname or date-of-birth
+	Score   float64 // Added to the score of the match; negative for differences
+	Detail  string
+}
+
+// Match is the result of comparing two officers
+type Match struct {
+	A, B    int     // Indices of the officers in their cluster, or the arguments of Compare
+	Score   float64 // Between 0 and 1
+	Reasons []Reason
+	Blocked bool // A difference rules out that the officers are the same person, e.g. another date of birth
+}
+
+// Cluster contains the officer records of a single person
+type Cluster struct {
+	Officers   []api.Officer
+	IDs        []string // The distinct officer IDs of the records
+	Confidence float64  // The lowest score of the matches joining the records; 1 for single records
+	Matches    []Match  // The matches joining the records
+}
+
+// Matcher compares and clusters officers
+type Matcher struct {
+	Threshold float64 // Minimum score of a match
+}
+
+// New returns a Matcher with the default threshold
+func New() *Matcher {
+	return &Matcher{Threshold: DefaultThreshold}
+}
+
+func (m *Matcher) add(match *Match, element string, score float64, format string, args ...interface{}) {
+	match.Score += score
+	match.Reasons = append(match.Reasons, Reason{Element: element, Score: score, Detail: fmt.Sprintf(format, args...)})
+}
+
+func (m *Matcher) block(match *Match, element string, format string, args ...interface{}) Match {
+	match.Blocked = true
+	match.Score = 0
+	match.Reasons = append(match.Reasons, Reason{Element: element, Detail: fmt.Sprintf(format, args...)})
+	return *match
+}
+
+// Compare returns the score of two officers being the same person, with the reasons for it
+func (m *Matcher) Compare(a, b api.Officer) Match {
+	match := Match{A: 0, B: 1}
+
+	if id := a.ID(); id != "" && id == b.ID() {
+		m.add(&match, "officer-id", 1, "same officer ID %s", id)
+		return match
+	}
+
+	if a.IsCorporate() || b.IsCorporate() {
+		return m.compareCorporate(match, a, b)
+	}
+
+	na, nb := a.ParsedName(), b.ParsedName()
+
+	if normalize(na.Surname) != normalize(nb.Surname) {
+		return m.block(&match, "surname", "surnames %q and %q differ", na.Surname, nb.Surname)
+	}
+	m.add(&match, "surname", surnameWeight, "same surname %s", na.Surname)
+
+	fa, fb := normalize(na.Forename()), normalize(nb.Forename())
+	switch {
+	case fa == "" || fb == "":
+	case fa == fb:
+		m.add(&match, "forename", forenameWeight, "same forename %s", na.Forename())
+	case len(fa) == 1 && strings.HasPrefix(fb, fa), len(fb) == 1 && strings.HasPrefix(fa, fb):
+		m.add(&match, "forename", initialWeight, "forename %s matches initial %s", longest(na.Forename(), nb.Forename()), shortest(na.Forename(), nb.Forename()))
+	default:
+		return m.block(&match, "forename", "forenames %q and %q differ", na.Forename(), nb.Forename())
+	}
+
+	ma, mb := na.MiddleNames(), nb.MiddleNames()
+	if len(ma) > 0 && len(mb) > 0 {
+		if compatibleNames(ma, mb) {
+			m.add(&match, "middle-names", middleNameWeight, "compatible middle names %q and %q", strings.Join(ma, " "), strings.Join(mb, " "))
+		} else {
+			m.add(&match, "middle-names", -middleNameWeight, "middle names %q and %q differ", strings.Join(ma, " "), strings.Join(mb, " "))
+		}
+	}
+
+	da, db := a.DateOfBirth, b.DateOfBirth
+	born := da.Year != 0 && db.Year != 0
+	if born {
+		if da.Year != db.Year || da.Month != db.Month {
+			return m.block(&match, "date-of-birth", "born %02d/%d and %02d/%d", da.Month, da.Year, db.Month, db.Year)
+		}
+		m.add(&match, "date-of-birth", birthDateWeight, "both born %02d/%d", da.Month, da.Year)
+	}
+
+	if a.Nationality != "" && b.Nationality != "" {
+		if strings.EqualFold(a.Nationality, b.Nationality) {
+			m.add(&match, "nationality", nationalityWeight, "both %s", a.Nationality)
+		} else {
+			m.add(&match, "nationality", -nationalityWeight, "nationalities %s and %s differ", a.Nationality, b.Nationality)
+		}
+	}
+
+	if a.CountryOfResidence != "" && b.CountryOfResidence != "" && strings.EqualFold(a.CountryOfResidence, b.CountryOfResidence) {
+		m.add(&match, "country-of-residence", residenceWeight, "both living in %s", a.CountryOfResidence)
+	}
+
+	m.compareAddresses(&match, a.Address, b.Address)
+
+	if !born && match.Score > MaxScoreWithoutBirthDate {
+		m.add(&match, "date-of-birth", MaxScoreWithoutBirthDate-match.Score, "no dates of birth to compare, so the score is at most %.2f", MaxScoreWithoutBirthDate)
+	}
+
+	if match.Score < 0 {
+		match.Score = 0
+	}
+	if match.Score > 1 {
+		match.Score = 1
+	}
+	return match
+}
+
+func (m *Matcher) compareCorporate(match Match, a, b api.Officer) Match {
+	if a.IsCorporate() != b.IsCorporate() {
+		return m.block(&match, "corporate", "only one of the officers is an organisation")
+	}
+
+	ra, rb := normalize(a.Identification.RegistrationNumber), normalize(b.Identification.RegistrationNumber)
+	if ra != "" && rb != "" {
+		if ra != rb {
+			return m.block(&match, "registration-number", "registration numbers %s and %s differ", a.Identification.RegistrationNumber, b.Identification.RegistrationNumber)
+		}
+		m.add(&match, "registration-number", 1, "same registration number %s", a.Identification.RegistrationNumber)
+		return match
+	}
+
+	if corporateName(a.Name) != corporateName(b.Name) {
+		return m.block(&match, "name", "names %q and %q differ", a.Name, b.Name)
+	}
+	m.add(&match, "name", surnameWeight+forenameWeight+birthDateWeight, "same name %s", a.Name)
+	m.compareAddresses(&match, a.Address, b.Address)
+	if match.Score > 1 {
+		match.Score = 1
+	}
+	return match
+}
+
+// compareAddresses adds the similarity of the service addresses
+func (m *Matcher) compareAddresses(match *Match, a, b api.Address) {
+	pa, pb := normalize(a.PostalCode), normalize(b.PostalCode)
+	if pa != "" && pa == pb {
+		m.add(match, "address", postcodeWeight, "same postcode %s", a.PostalCode)
+		return
+	}
+
+	if s := similarity(addressWords(a), addressWords(b)); s > 0 {
+		m.add(match, "address", addressWeight*s, "addresses are %.0f%% similar", s*100)
+	}
+}
+
+// Cluster groups the officers which are the same person. Clusters are never joined if any of their
+// records contradict each other, e.g. by date of birth. Clusters are sorted by the position of
+// their first officer, and officers within a cluster keep their order.
+func (m *Matcher) Cluster(officers []api.Officer) []Cluster {
+	parent := make([]int, len(officers))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	members := make(map[int][]int) // Officers of each root
+	for i := range officers {
+		members[i] = []int{i}
+	}
+
+	var links []Match
+	link := func(i, j int, match Match) {
+		match.A, match.B = i, j
+		links = append(links, match)
+		ri, rj := find(i), find(j)
+		if ri != rj {
+			parent[rj] = ri
+			members[ri] = append(members[ri], members[rj]...)
+			delete(members, rj)
+		}
+	}
+
+	// conflict returns true if any pair of officers across two clusters can't be the same person,
+	// which prevents a record with few details from chaining different people together
+	conflict := func(ri, rj int) bool {
+		for _, x := range members[ri] {
+			for _, y := range members[rj] {
+				if contradicts(officers[x], officers[y]) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	// Records with the same officer ID are always the same person, even after a change of name
+	first := make(map[string]int)
+	for i, o := range officers {
+		id := o.ID()
+		if id == "" {
+			continue
+		}
+		if j, ok := first[id]; ok {
+			link(j, i, m.Compare(officers[j], o))
+			continue
+		}
+		first[id] = i
+	}
+
+	// Otherwise only officers with the same surname, organisation name or registration number can match
+	blocks := make(map[string][]int)
+	for i, o := range officers {
+		for _, key := range blockKeys(o) {
+			blocks[key] = append(blocks[key], i)
+		}
+	}
+
+	type pair struct{ i, j int }
+	compared := make(map[pair]bool)
+	var candidates []Match
+	for _, block := range blocks {
+		for x := 0; x < len(block); x++ {
+			for y := x + 1; y < len(block); y++ {
+				i, j := block[x], block[y]
+				if compared[pair{i, j}] {
+					continue
+				}
+				compared[pair{i, j}] = true
+
+				if id := officers[i].ID(); id != "" && id == officers[j].ID() {
+					continue
+				}
+				match := m.Compare(officers[i], officers[j])
+				if match.Blocked || match.Score < m.Threshold {
+					continue
+				}
+				match.A, match.B = i, j
+				candidates = append(candidates, match)
+			}
+		}
+	}
+
+	// Merge the strongest matches first
+	sort.Slice(candidates, func(x, y int) bool {
+		if candidates[x].Score != candidates[y].Score {
+			return candidates[x].Score > candidates[y].Score
+		}
+		if candidates[x].A != candidates[y].A {
+			return candidates[x].A < candidates[y].A
+		}
+		return candidates[x].B < candidates[y].B
+	})
+	for _, match := range candidates {
+		ri, rj := find(match.A), find(match.B)
+		if ri != rj && conflict(ri, rj) {
+			continue
+		}
+		link(match.A, match.B, match)
+	}
+
+	byRoot := make(map[int]*Cluster)
+	index := make(map[int]int) // Index of an officer within its cluster
+	var roots []int
+	for i, o := range officers {
+		r := find(i)
+		c, ok := byRoot[r]
+		if !ok {
+			c = &Cluster{Confidence: 1}
+			byRoot[r] = c
+			roots = append(roots, r)
+		}
+		index[i] = len(c.Officers)
+		c.Officers = append(c.Officers, o)
+		if id := o.ID(); id != "" && !contains(c.IDs, id) {
+			c.IDs = append(c.IDs, id)
+		}
+	}
+
+	sort.Slice(links, func(i, j int) bool {
+		if links[i].A != links[j].A {
+			return links[i].A < links[j].A
+		}
+		return links[i].B < links[j].B
+	})
+	for _, link := range links {
+		c := byRoot[find(link.A)]
+		if link.Score < c.Confidence {
+			c.Confidence = link.Score
+		}
+		link.A, link.B = index[link.A], index[link.B]
+		c.Matches = append(c.Matches, link)
+	}
+
+	res := make([]Cluster, 0, len(roots))
+	for _, r := range roots {
+		res = append(res, *byRoot[r])
+	}
+	return res
+}
+
+// contradicts returns true if the attributes of two officers which don't change, unlike their names,
+// block a match: the date of birth, the registration number or being an organisation
+func contradicts(a, b api.Officer) bool {
+	if a.IsCorporate() != b.IsCorporate() {
+		return true
+	}
+
+	ra, rb := normalize(a.Identification.RegistrationNumber), normalize(b.Identification.RegistrationNumber)
+	if ra != "" && rb != "" && ra != rb {
+		return true
+	}
+
+	da, db := a.DateOfBirth, b.DateOfBirth
+	return da.Year != 0 && db.Year != 0 && (da.Year != db.Year || da.Month != db.Month)
+}
+
+// blockKeys returns the keys of the groups of officers an officer is compared with
+func blockKeys(o api.Officer) []string {
+	if !o.IsCorporate() {
+		return []string{normalize(o.ParsedName().Surname)}
+	}
+
+	keys := []string{"corporate:" + corporateName(o.Name)}
+	if number := normalize(o.Identification.RegistrationNumber); number != "" {
+		keys = append(keys, "registration:"+number)
+	}
+	return keys
+}
+
+// normalize returns the letters and digits of s in lower case
+func normalize(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// corporateName returns the normalized name of an organisation. Legal forms are abbreviated, so
+// LIMITED and LTD are the same, but LTD and PLC differ.
+func corporateName(name string) string {
+	var words []string
+	for _, w := range strings.Fields(strings.ToLower(name)) {
+		w = normalize(w)
+		switch w {
+		case "", "the":
+			continue
+		case "limited":
+			w = "ltd"
+		case "incorporated":
+			w = "inc"
+		case "corporation":
+			w = "corp"
+		}
+		words = append(words, w)
+	}
+	return strings.Join(words, " ")
+}
+
+// compatibleNames returns true if the names are equal, or one is the initial of the other
+func compatibleNames(a, b []string) bool {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		x, y := normalize(a[i]), normalize(b[i])
+		if x == y {
+			continue
+		}
+		if (len(x) == 1 && strings.HasPrefix(y, x)) || (len(y) == 1 && strings.HasPrefix(x, y)) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+func addressWords(a api.Address) map[string]bool {
+	words := make(map[string]bool)
+	for _, s := range []string{a.Premises, a.AddressLine1, a.AddressLine2, a.Locality, a.Region} {
+		for _, w := range strings.Fields(s) {
+			if w = normalize(w); w != "" {
+				words[w] = true
+			}
+		}
+	}
+	return words
+}
+
+// similarity returns the Jaccard index of two sets of words
+func similarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	common := 0
+	for w := range a {
+		if b[w] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(a)+len(b)-common)
+}
+
+func longest(a, b string) string {
+	if len(a) >= len(b) {
+		return a
+	}
+	return b
+}
+
+func shortest(a, b string) string {
+	if len(a) < len(b) {
+		return a
+	}
+	return b
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
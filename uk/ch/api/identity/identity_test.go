@@ -0,0 +1,146 @@
+package identity_test
+
+import (
+	"testing"
+
+	ch "github.com/appinesshq/globire-go/uk/ch/api"
+	"github.com/appinesshq/globire-go/uk/ch/api/identity"
+)
+
+func officer(id, name string, month, year int, postcode string) ch.Officer {
+	o := ch.Officer{Name: name, Nationality: "British", CountryOfResidence: "England", OfficerRole: "director"}
+	o.DateOfBirth = ch.OfficerDateOfBirth{Month: month, Year: year}
+	o.Address.PostalCode = postcode
+	o.Links.Officer.Appointments = "/officers/" + id + "/appointments"
+	return o
+}
+
+func TestCompare(t *testing.T) {
+	m := identity.New()
+
+	match := m.Compare(officer("a", "PERSON, Test John", 1, 1980, "TS1 2TS"), officer("b", "PERSON, T J", 1, 1980, "TS1 2TS"))
+	if match.Blocked || match.Score < m.Threshold {
+		t.Errorf("expected a match, but got %+v", match)
+	}
+	if len(match.Reasons) == 0 {
+		t.Errorf("expected reasons for the match")
+	}
+
+	match = m.Compare(officer("a", "PERSON, Test", 1, 1980, ""), officer("b", "PERSON, Test", 2, 1980, ""))
+	if !match.Blocked {
+		t.Errorf("expected another date of birth to block the match, but got %+v", match)
+	}
+
+	match = m.Compare(officer("a", "PERSON, Test", 0, 0, ""), officer("b", "PERSON, Other", 0, 0, ""))
+	if !match.Blocked {
+		t.Errorf("expected another forename to block the match, but got %+v", match)
+	}
+
+	// A shared name and service address isn't enough without a date of birth
+	match = m.Compare(officer("a", "SMITH, John", 0, 0, "TS1 2TS"), officer("b", "SMITH, John", 0, 0, "TS1 2TS"))
+	if match.Blocked || match.Score >= m.Threshold {
+		t.Errorf("expected no match without dates of birth, but got %+v", match)
+	}
+	if got, expected := match.Score, identity.MaxScoreWithoutBirthDate; got != expected {
+		t.Errorf("expected score %v, but got %v", expected, got)
+	}
+}
+
+func TestCompareCorporate(t *testing.T) {
+	m := identity.New()
+	a := ch.Officer{Name: "ACME LIMITED", OfficerRole: "corporate-director"}
+	b := ch.Officer{Name: "ACME LTD", OfficerRole: "corporate-secretary"}
+	c := ch.Officer{Name: "ACME PLC", OfficerRole: "corporate-director"}
+
+	if match := m.Compare(a, b); match.Blocked || match.Score < m.Threshold {
+		t.Errorf("expected LIMITED and LTD to match, but got %+v", match)
+	}
+	if match := m.Compare(a, c); !match.Blocked {
+		t.Errorf("expected LIMITED and PLC to differ, but got %+v", match)
+	}
+}
+
+func TestCluster(t *testing.T) {
+	corporate := ch.Officer{Name: "PARENT LIMITED", OfficerRole: "corporate-director"}
+	corporate.Identification.RegistrationNumber = "87654321"
+	corporate2 := ch.Officer{Name: "PARENT LTD", OfficerRole: "corporate-secretary"}
+	corporate2.Identification.RegistrationNumber = "87654321"
+
+	officers := []ch.Officer{
+		officer("a", "PERSON, Test John", 1, 1980, "TS1 2TS"),
+		officer("b", "PERSON, Test", 1, 1980, "TS9 9ZZ"),
+		officer("c", "PERSON, Test", 5, 1955, "TS1 2TS"),
+		officer("a", "MARRIED, Test John", 1, 1980, "TS1 2TS"),
+		corporate,
+		corporate2,
+	}
+
+	clusters := identity.New().Cluster(officers)
+	if got, expected := len(clusters), 3; got != expected {
+		t.Fatalf("expected %d clusters, but got %d: %+v", expected, got, clusters)
+	}
+
+	c := clusters[0]
+	if got, expected := len(c.Officers), 3; got != expected {
+		t.Errorf("expected %d records, but got %d", expected, got)
+	}
+	if got, expected := len(c.IDs), 2; got != expected {
+		t.Errorf("expected %d officer IDs, but got %d", expected, got)
+	}
+	if c.Confidence <= 0 || c.Confidence >= 1 {
+		t.Errorf("expected a confidence below 1, but got %v", c.Confidence)
+	}
+	if got, expected := len(c.Matches), 2; got != expected {
+		t.Errorf("expected %d matches, but got %d", expected, got)
+	}
+
+	if got, expected := len(clusters[1].Officers), 1; got != expected {
+		t.Errorf("expected %d record, but got %d", expected, got)
+	}
+	if got, expected := len(clusters[2].Officers), 2; got != expected {
+		t.Errorf("expected %d corporate records, but got %d", expected, got)
+	}
+}
+
+func TestClusterChain(t *testing.T) {
+	// The record without a date of birth matches both people, but can't join them into one cluster
+	officers := []ch.Officer{
+		officer("a", "PERSON, Test", 1, 1980, "TS1 2TS"),
+		officer("b", "PERSON, Test", 5, 1955, "TS1 2TS"),
+		officer("c", "PERSON, Test", 0, 0, "TS1 2TS"),
+	}
+
+	// A lower threshold lets the record without a date of birth match
+	m := &identity.Matcher{Threshold: 0.5}
+	if match := m.Compare(officers[0], officers[2]); match.Blocked || match.Score < m.Threshold {
+		t.Fatalf("expected the record without a date of birth to match, but got %+v", match)
+	}
+
+	clusters := m.Cluster(officers)
+	if got, expected := len(clusters), 2; got != expected {
+		t.Fatalf("expected %d clusters, but got %d", expected, got)
+	}
+	for _, c := range clusters {
+		years := map[int]bool{}
+		for _, o := range c.Officers {
+			if o.DateOfBirth.Year != 0 {
+				years[o.DateOfBirth.Year] = true
+			}
+		}
+		if len(years) > 1 {
+			t.Errorf("expected people born in different years in separate clusters, but got %v", years)
+		}
+	}
+}
+
+func TestClusterRegistrationNumber(t *testing.T) {
+	a := ch.Officer{Name: "PARENT LIMITED", OfficerRole: "corporate-director"}
+	a.Identification.RegistrationNumber = "87654321"
+	b := ch.Officer{Name: "NEW NAME HOLDINGS LIMITED", OfficerRole: "corporate-director"}
+	b.Identification.RegistrationNumber = "87654321"
+
+	clusters := identity.New().Cluster([]ch.Officer{a, b})
+	if got, expected := len(clusters), 1; got != expected {
+		t.Fatalf("expected %d cluster for a renamed company, but got %d", expected, got)
+	}
+}
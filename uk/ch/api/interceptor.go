@@ -0,0 +1,181 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Interceptor hooks into every request made through DoRequest, e.g. for logging, request IDs,
+// metrics, rotating credentials or injecting faults. Any of the functions may be nil.
+// BeforeRequest is called in the order of registration, AfterResponse and OnError in reverse order.
+type Interceptor struct {
+	// BeforeRequest is called before the request is sent. It returns the request to send, which
+	// may be modified or carry additional context values, or an error to abort the request.
+	BeforeRequest func(req *http.Request) (*http.Request, error)
+
+	// AfterResponse is called after a successful response. An error fails the request.
+	AfterResponse func(req *http.Request, resp *http.Response) error
+
+	// OnError is called instead of AfterResponse when the request fails, including responses with
+	// an error status. It isn't called if the request was aborted before this interceptor.
+	// resp is nil if no response was received.
+	OnError func(req *http.Request, resp *http.Response, err error)
+}
+
+// Use adds interceptors to the API
+func (a *API) Use(interceptors ...Interceptor) {
+	a.Interceptors = append(a.Interceptors, interceptors...)
+}
+
+// WithInterceptors adds interceptors to the API, see Interceptor
+func WithInterceptors(interceptors ...Interceptor) ClientOption {
+	return func(a *API) error {
+		a.Use(interceptors...)
+		return nil
+	}
+}
+
+// intercepted calls the OnError functions of the first n interceptors and returns the error
+func (a *API) intercepted(n int, req *http.Request, resp *http.Response, err error) error {
+	for i := n - 1; i >= 0; i-- {
+		if ic := a.Interceptors[i]; ic.OnError != nil {
+			ic.OnError(req, resp, err)
+		}
+	}
+	return err
+}
+
+// Logger is a structured logger, which logs a message with alternating keys and values
+type Logger interface {
+	Log(msg string, keyvals ...interface{})
+}
+
+// LoggerFunc is an adapter to use a function as a Logger
+type LoggerFunc func(msg string, keyvals ...interface{})
+
+// Log implements the Logger interface
+func (f LoggerFunc) Log(msg string, keyvals ...interface{}) {
+	f(msg, keyvals...)
+}
+
+// TextLogger returns a Logger which writes a line per message to w, like
+// request method=GET path=/company/12345678 status=200 duration=12ms
+func TextLogger(w io.Writer) Logger {
+	var mu sync.Mutex
+	return LoggerFunc(func(msg string, keyvals ...interface{}) {
+		var b strings.Builder
+		b.WriteString(msg)
+		for i := 0; i < len(keyvals); i += 2 {
+			var v interface{} = "(missing)"
+			if i+1 < len(keyvals) {
+				v = keyvals[i+1]
+			}
+			s := fmt.Sprint(v)
+			if strings.ContainsAny(s, " =\"") {
+				s = fmt.Sprintf("%q", s)
+			}
+			fmt.Fprintf(&b, " %v=%s", keyvals[i], s)
+		}
+		b.WriteByte('\n')
+
+		mu.Lock()
+		defer mu.Unlock()
+		io.WriteString(w, b.String())
+	})
+}
+
+type startKey struct{}
+
+// withStart returns the request with the current time in its context, to measure its duration
+func withStart(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), startKey{}, time.Now()))
+}
+
+// started returns the time set by withStart
+func started(req *http.Request) (time.Time, bool) {
+	t, ok := req.Context().Value(startKey{}).(time.Time)
+	return t, ok
+}
+
+// LogInterceptor returns an interceptor which logs every request with its status and duration
+func LogInterceptor(l Logger) Interceptor {
+	duration := func(req *http.Request) time.Duration {
+		start, ok := started(req)
+		if !ok {
+			return 0
+		}
+		return time.Since(start).Round(time.Millisecond)
+	}
+
+	return Interceptor{
+		BeforeRequest: func(req *http.Request) (*http.Request, error) {
+			return withStart(req), nil
+		},
+		AfterResponse: func(req *http.Request, resp *http.Response) error {
+			l.Log("request", "method", req.Method, "path", req.URL.Path, "status", resp.StatusCode, "duration", duration(req))
+			return nil
+		},
+		OnError: func(req *http.Request, resp *http.Response, err error) {
+			kv := []interface{}{"method", req.Method, "path", req.URL.Path}
+			if resp != nil {
+				kv = append(kv, "status", resp.StatusCode)
+			}
+			l.Log("request failed", append(kv, "duration", duration(req), "error", err)...)
+		},
+	}
+}
+
+// DebugInterceptor returns an interceptor which dumps requests and responses, including their
+// bodies, to w. The API key is redacted.
+func DebugInterceptor(w io.Writer) Interceptor {
+	var mu sync.Mutex
+	write := func(b []byte) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Write(b)
+		io.WriteString(w, "\n\n")
+	}
+
+	dumpResponse := func(resp *http.Response) {
+		if b, err := httputil.DumpResponse(resp, true); err == nil {
+			write(b)
+		}
+	}
+
+	return Interceptor{
+		BeforeRequest: func(req *http.Request) (*http.Request, error) {
+			// DumpRequestOut reads the body and replaces it with a copy, so the clone is sent instead
+			out := req.Clone(req.Context())
+			auth := out.Header.Get("Authorization")
+			if auth != "" {
+				out.Header.Set("Authorization", "REDACTED")
+			}
+
+			b, err := httputil.DumpRequestOut(out, true)
+			if auth != "" {
+				out.Header.Set("Authorization", auth)
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			write(b)
+			return out, nil
+		},
+		AfterResponse: func(req *http.Request, resp *http.Response) error {
+			dumpResponse(resp)
+			return nil
+		},
+		OnError: func(req *http.Request, resp *http.Response, err error) {
+			if resp != nil {
+				dumpResponse(resp)
+			}
+		},
+	}
+}
@@ -0,0 +1,160 @@
+package api_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	ch "github.com/appinesshq/globire-go/uk/ch/api"
+	"github.com/appinesshq/globire-go/uk/ch/api/tests"
+)
+
+func TestInterceptors(t *testing.T) {
+	var calls []string
+	record := func(name string) ch.Interceptor {
+		return ch.Interceptor{
+			BeforeRequest: func(req *http.Request) (*http.Request, error) {
+				calls = append(calls, name+" before")
+				req.Header.Set("X-Request-Id", "abc")
+				return req, nil
+			},
+			AfterResponse: func(req *http.Request, resp *http.Response) error {
+				calls = append(calls, name+" after "+req.Header.Get("X-Request-Id"))
+				return nil
+			},
+			OnError: func(req *http.Request, resp *http.Response, err error) {
+				if resp == nil {
+					calls = append(calls, name+" error "+err.Error())
+					return
+				}
+				calls = append(calls, name+" error "+resp.Status)
+			},
+		}
+	}
+
+	api, err := ch.New("test", ch.WithInterceptors(record("a"), record("b")))
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	ts := tests.NewMockServer()
+	defer ts.Close()
+	api.URL, err = url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	if _, err := api.GetCompany("12345678"); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if _, err := api.GetCompany("00000000"); err == nil {
+		t.Fatalf("expected an error for an unknown company, but got none")
+	}
+
+	expected := "a before, b before, b after abc, a after abc, a before, b before, b error 404 Not Found, a error 404 Not Found"
+	if got := strings.Join(calls, ", "); got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	// An error before the request aborts it, and only the interceptors which saw it start get the error
+	calls = nil
+	api.Use(ch.Interceptor{BeforeRequest: func(req *http.Request) (*http.Request, error) {
+		return nil, errors.New("fault")
+	}}, record("c"))
+	if _, err := api.GetCompany("12345678"); err == nil || !strings.Contains(err.Error(), "fault") {
+		t.Errorf("expected an injected fault, but got: %v", err)
+	}
+
+	expected = "a before, b before, b error before request: fault, a error before request: fault"
+	if got := strings.Join(calls, ", "); got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+}
+
+func TestInterceptorAfterResponseError(t *testing.T) {
+	var calls []string
+	api, err := ch.New("test", ch.WithInterceptors(ch.Interceptor{
+		AfterResponse: func(req *http.Request, resp *http.Response) error {
+			calls = append(calls, "a after")
+			return errors.New("rejected")
+		},
+		OnError: func(req *http.Request, resp *http.Response, err error) {
+			calls = append(calls, "a error")
+		},
+	}, ch.Interceptor{
+		AfterResponse: func(req *http.Request, resp *http.Response) error {
+			calls = append(calls, "b after")
+			return nil
+		},
+		OnError: func(req *http.Request, resp *http.Response, err error) {
+			calls = append(calls, "b error")
+		},
+	}))
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	ts := tests.NewMockServer()
+	defer ts.Close()
+	api.URL, err = url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	if _, err := api.GetCompany("12345678"); err == nil || !strings.Contains(err.Error(), "rejected") {
+		t.Errorf("expected the response to be rejected, but got: %v", err)
+	}
+
+	// Interceptors get either AfterResponse or OnError, never both
+	if got, expected := strings.Join(calls, ", "), "b after, a after"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+}
+
+func TestLogAndDebugInterceptors(t *testing.T) {
+	var logs, dump bytes.Buffer
+	api, err := ch.New("secret", ch.WithInterceptors(ch.LogInterceptor(ch.TextLogger(&logs)), ch.DebugInterceptor(&dump)))
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	ts := tests.NewMockServer()
+	defer ts.Close()
+	api.URL, err = url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	c, err := api.GetCompany("12345678")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if got, expected := c.CompanyNumber, "12345678"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+	api.GetCompany("00000000")
+
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, but got %q", logs.String())
+	}
+	if expected := "request method=GET path=/company/12345678 status=200 duration="; !strings.HasPrefix(lines[0], expected) {
+		t.Errorf("expected %q, but got %q", expected, lines[0])
+	}
+	if expected := "request failed method=GET path=/company/00000000 status=404"; !strings.HasPrefix(lines[1], expected) {
+		t.Errorf("expected %q, but got %q", expected, lines[1])
+	}
+
+	out := dump.String()
+	if !strings.Contains(out, "Authorization: REDACTED") {
+		t.Errorf("expected the API key to be redacted, but got %q", out)
+	}
+	for _, s := range []string{"GET /company/12345678", "\"company_number\": \"12345678\"", "Not found"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected the dump to contain %q, but got %q", s, out)
+		}
+	}
+}
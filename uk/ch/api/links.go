@@ -0,0 +1,354 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// CompanyLinks contains the links of a company profile
+	CompanyLinks struct {
+		Charges                                 string `json:"charges"`
+		FilingHistory                           string `json:"filing_history"`
+		Insolvency                              string `json:"insolvency"`
+		Officers                                string `json:"officers"`
+		PersonsWithSignificantControl           string `json:"persons_with_significant_control"`
+		PersonsWithSignificantControlStatements string `json:"persons_with_significant_control_statements"`
+		Registers                               string `json:"registers"`
+		Self                                    string `json:"self"`
+	}
+
+	// CompanyProfileLinks contains the link to a company profile from a search result
+	CompanyProfileLinks struct {
+		CompanyProfile string `json:"company_profile"`
+	}
+
+	// CompanySearchResultLinks contains the links of a company search result
+	CompanySearchResultLinks struct {
+		Self string `json:"self"`
+	}
+
+	// OfficersLinks contains the links of a list of officers
+	OfficersLinks struct {
+		Self string `json:"self"`
+	}
+
+	// OfficerLinks contains the links of an officer
+	OfficerLinks struct {
+		Officer struct {
+			Appointments string `json:"appointments"`
+		} `json:"officer"`
+	}
+
+	// OfficerSearchResultLinks contains the links of an officer search result
+	OfficerSearchResultLinks struct {
+		Self string `json:"self"` // The appointments of the officer
+	}
+
+	// AppointmentLinks contains the links of an appointment
+	AppointmentLinks struct {
+		Company string `json:"company"`
+	}
+
+	// AppointmentsLinks contains the links of a list of appointments
+	AppointmentsLinks struct {
+		Self string `json:"self"`
+	}
+
+	// FilingLinks contains the links of a filing
+	FilingLinks struct {
+		Self             string `json:"self"`
+		DocumentMetadata string `json:"document_metadata"`
+	}
+
+	// DocumentMetadataLinks contains the links of the metadata of a document
+	DocumentMetadataLinks struct {
+		Self     string `json:"self"`
+		Document string `json:"document"` // The content of the document
+	}
+
+	// ChargeLinks contains the links of a charge
+	ChargeLinks struct {
+		Self string `json:"self"`
+	}
+
+	// ChargeTransactionLinks contains the links of a transaction of a charge
+	ChargeTransactionLinks struct {
+		Filing         string `json:"filing"`
+		InsolvencyCase string `json:"insolvency_case"`
+	}
+
+	// PSCLinks contains the links of a person with significant control
+	PSCLinks struct {
+		Self      string `json:"self"`
+		Statement string `json:"statement"`
+	}
+
+	// PSCStatementLinks contains the links of a statement about persons with significant control
+	PSCStatementLinks struct {
+		Self                         string `json:"self"`
+		PersonWithSignificantControl string `json:"person_with_significant_control"`
+	}
+
+	// PSCsLinks contains the links of a list of persons with significant control
+	PSCsLinks struct {
+		Self                                    string `json:"self"`
+		PersonsWithSignificantControlStatements string `json:"persons_with_significant_control_statements"`
+	}
+)
+
+// Follow gets the resource of a link in a response and decodes it into v, which should be a pointer.
+// Links to the document API resolve against the DocumentURL of the environment, see ResolveLink.
+func (a *API) Follow(ctx context.Context, link string, v interface{}) error {
+	u, err := a.ResolveLink(link)
+	if err != nil {
+		return err
+	}
+
+	resp, err := a.request(ctx, http.MethodGet, u, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return errors.Wrap(err, "decoding response")
+	}
+
+	// Link the company to the API, so its sub-resources can be requested
+	if c, ok := v.(*Company); ok {
+		c.api = a
+	}
+
+	return nil
+}
+
+// follow follows a link, or returns an error if the response didn't contain it
+func follow(ctx context.Context, a *API, name, link string, v interface{}) error {
+	if link == "" {
+		return fmt.Errorf("no %s link", name)
+	}
+	return a.Follow(ctx, link, v)
+}
+
+// FollowSelf gets the company profile
+func (l CompanyLinks) FollowSelf(ctx context.Context, a *API) (*Company, error) {
+	res := Company{}
+	if err := follow(ctx, a, "self", l.Self, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// FollowOfficers gets the first page of the company's officers
+func (l CompanyLinks) FollowOfficers(ctx context.Context, a *API) (*Officers, error) {
+	res := Officers{}
+	if err := follow(ctx, a, "officers", l.Officers, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// FollowFilingHistory gets the first page of the company's filing history
+func (l CompanyLinks) FollowFilingHistory(ctx context.Context, a *API) (*FilingHistory, error) {
+	res := FilingHistory{}
+	if err := follow(ctx, a, "filing history", l.FilingHistory, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// FollowCharges gets the first page of the company's charges
+func (l CompanyLinks) FollowCharges(ctx context.Context, a *API) (*Charges, error) {
+	res := Charges{}
+	if err := follow(ctx, a, "charges", l.Charges, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// FollowPSCs gets the first page of the company's persons with significant control
+func (l CompanyLinks) FollowPSCs(ctx context.Context, a *API) (*PSCs, error) {
+	res := PSCs{}
+	if err := follow(ctx, a, "persons with significant control", l.PersonsWithSignificantControl, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// FollowCompanyProfile gets the company profile of the search result
+func (l CompanyProfileLinks) FollowCompanyProfile(ctx context.Context, a *API) (*Company, error) {
+	res := Company{}
+	if err := follow(ctx, a, "company profile", l.CompanyProfile, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// FollowSelf gets the company profile of the search result
+func (l CompanySearchResultLinks) FollowSelf(ctx context.Context, a *API) (*Company, error) {
+	res := Company{}
+	if err := follow(ctx, a, "self", l.Self, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// FollowSelf gets the page of officers again
+func (l OfficersLinks) FollowSelf(ctx context.Context, a *API) (*Officers, error) {
+	res := Officers{}
+	if err := follow(ctx, a, "self", l.Self, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// FollowAppointments gets the first page of the officer's appointments
+func (l OfficerLinks) FollowAppointments(ctx context.Context, a *API) (*Appointments, error) {
+	res := Appointments{}
+	if err := follow(ctx, a, "appointments", l.Officer.Appointments, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// FollowSelf gets the first page of the appointments of the officer in the search result
+func (l OfficerSearchResultLinks) FollowSelf(ctx context.Context, a *API) (*Appointments, error) {
+	res := Appointments{}
+	if err := follow(ctx, a, "self", l.Self, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// FollowCompany gets the profile of the company of the appointment
+func (l AppointmentLinks) FollowCompany(ctx context.Context, a *API) (*Company, error) {
+	res := Company{}
+	if err := follow(ctx, a, "company", l.Company, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// FollowSelf gets the page of appointments again
+func (l AppointmentsLinks) FollowSelf(ctx context.Context, a *API) (*Appointments, error) {
+	res := Appointments{}
+	if err := follow(ctx, a, "self", l.Self, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// FollowSelf gets the filing
+func (l FilingLinks) FollowSelf(ctx context.Context, a *API) (*Filing, error) {
+	res := Filing{}
+	if err := follow(ctx, a, "self", l.Self, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// FollowDocumentMetadata gets the metadata of the filing's document from the document API
+func (l FilingLinks) FollowDocumentMetadata(ctx context.Context, a *API) (*DocumentMetadata, error) {
+	res := DocumentMetadata{}
+	if err := follow(ctx, a, "document metadata", l.DocumentMetadata, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// FollowSelf gets the metadata of the document again
+func (l DocumentMetadataLinks) FollowSelf(ctx context.Context, a *API) (*DocumentMetadata, error) {
+	res := DocumentMetadata{}
+	if err := follow(ctx, a, "self", l.Self, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// FollowDocument gets the content of the document in the provided content type, e.g. application/pdf.
+// The available content types are the keys of the Resources of the metadata. The caller should close the content.
+func (l DocumentMetadataLinks) FollowDocument(ctx context.Context, a *API, contentType string) (io.ReadCloser, error) {
+	if l.Document == "" {
+		return nil, fmt.Errorf("no document link")
+	}
+
+	u, err := a.ResolveLink(l.Document)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := a.request(ctx, http.MethodGet, u, http.Header{"Accept": {contentType}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// FollowSelf gets the charge
+func (l ChargeLinks) FollowSelf(ctx context.Context, a *API) (*Charge, error) {
+	res := Charge{}
+	if err := follow(ctx, a, "self", l.Self, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// FollowFiling gets the filing of the transaction
+func (l ChargeTransactionLinks) FollowFiling(ctx context.Context, a *API) (*Filing, error) {
+	res := Filing{}
+	if err := follow(ctx, a, "filing", l.Filing, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// FollowSelf gets the person with significant control
+func (l PSCLinks) FollowSelf(ctx context.Context, a *API) (*PSC, error) {
+	res := PSC{}
+	if err := follow(ctx, a, "self", l.Self, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// FollowStatement gets the statement linked to the person with significant control
+func (l PSCLinks) FollowStatement(ctx context.Context, a *API) (*PSCStatement, error) {
+	res := PSCStatement{}
+	if err := follow(ctx, a, "statement", l.Statement, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// FollowSelf gets the statement
+func (l PSCStatementLinks) FollowSelf(ctx context.Context, a *API) (*PSCStatement, error) {
+	res := PSCStatement{}
+	if err := follow(ctx, a, "self", l.Self, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// FollowPSC gets the person with significant control the statement is about
+func (l PSCStatementLinks) FollowPSC(ctx context.Context, a *API) (*PSC, error) {
+	res := PSC{}
+	if err := follow(ctx, a, "person with significant control", l.PersonWithSignificantControl, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// FollowSelf gets the page of persons with significant control again
+func (l PSCsLinks) FollowSelf(ctx context.Context, a *API) (*PSCs, error) {
+	res := PSCs{}
+	if err := follow(ctx, a, "self", l.Self, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
@@ -0,0 +1,138 @@
+package api_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/url"
+	"testing"
+
+	ch "github.com/appinesshq/globire-go/uk/ch/api"
+	"github.com/appinesshq/globire-go/uk/ch/api/tests"
+)
+
+func TestFollowLinks(t *testing.T) {
+	api, err := ch.New("test")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	ts := tests.NewMockServer()
+	defer ts.Close()
+	api.URL, err = url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	ctx := context.Background()
+	c, err := api.GetCompany("12345678")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	officers, err := c.Links.FollowOfficers(ctx, api)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if len(officers.Items) == 0 {
+		t.Fatalf("expected officers, but got none")
+	}
+
+	appointments, err := officers.Items[0].Links.FollowAppointments(ctx, api)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if len(appointments.Items) != 2 {
+		t.Fatalf("expected 2 appointments, but got %d", len(appointments.Items))
+	}
+
+	parent, err := appointments.Items[1].Links.FollowCompany(ctx, api)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if got, expected := parent.CompanyNumber, "87654321"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	// The followed company is linked to the API
+	if _, err := parent.Officers(); err != nil {
+		t.Errorf("expected to pass, but got: %v", err)
+	}
+
+	if _, err := c.Links.FollowPSCs(ctx, api); err != nil {
+		t.Errorf("expected to pass, but got: %v", err)
+	}
+
+	if res, err := (ch.CompanyLinks{}).FollowCharges(ctx, api); err == nil || res != nil {
+		t.Errorf("expected only an error for a missing link, but got %v, %v", res, err)
+	}
+
+	if res, err := (ch.CompanyLinks{Officers: "/company/00000000/officers"}).FollowOfficers(ctx, api); err == nil || res != nil {
+		t.Errorf("expected only an error for a failed request, but got %v, %v", res, err)
+	}
+}
+
+func TestFollowDocument(t *testing.T) {
+	ts := tests.NewMockServer()
+	defer ts.Close()
+
+	api, err := ch.New("test", ch.WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	ctx := context.Background()
+	c, err := api.GetCompany("12345678")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	filings, err := c.FilingHistory()
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	m, err := filings.Items[0].Links.FollowDocumentMetadata(ctx, api)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if got, expected := m.Barcode, "X9A1B2C3"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if m, err = m.Links.FollowSelf(ctx, api); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	content, err := m.Links.FollowDocument(ctx, api, "application/pdf")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	defer content.Close()
+
+	b, err := ioutil.ReadAll(content)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if got, expected := len(b), m.Resources["application/pdf"].ContentLength; got != expected {
+		t.Errorf("expected %d bytes, but got %d", expected, got)
+	}
+
+	if _, err := m.Links.FollowDocument(ctx, api, "application/xhtml+xml"); err == nil {
+		t.Errorf("expected an error for an unavailable content type, but got none")
+	}
+}
+
+func TestOfficerID(t *testing.T) {
+	cases := map[string]string{
+		"/officers/e4-ScyHpxNNUh6ZyV9wnqZS1kfY/appointments": "e4-ScyHpxNNUh6ZyV9wnqZS1kfY",
+		"":                           "",
+		"/company/12345678/officers": "",
+	}
+
+	for link, expected := range cases {
+		o := ch.Officer{}
+		o.Links.Officer.Appointments = link
+		if got := o.ID(); got != expected {
+			t.Errorf("expected %q, but got %q", expected, got)
+		}
+	}
+}
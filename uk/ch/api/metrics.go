@@ -0,0 +1,337 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// Metrics receives measurements of the requests made by the API. Registry is a built-in implementation,
+// other monitoring systems can be plugged in by implementing the interface.
+type Metrics interface {
+	ObserveLatency(endpoint string, d time.Duration)
+	IncStatus(endpoint string, code int) // code is 0 if no response was received
+	IncRetry(endpoint string)
+	SetRateLimitRemaining(n int)
+	IncCache(hit bool)
+}
+
+// WithMetrics reports the requests of the API, and its cache hits if it has a Store, to m
+func WithMetrics(m Metrics) ClientOption {
+	return func(a *API) error {
+		a.Metrics = m
+		a.Use(MetricsInterceptor(m))
+		return nil
+	}
+}
+
+type retryKey struct{}
+
+// RetryAttempt marks requests made with the returned context as a retry, if attempt is more than 0.
+// Retries are counted by the metrics per endpoint. The context only reaches requests made through
+// Do and DoRequest; the other methods of the API use context.Background.
+func RetryAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, retryKey{}, attempt)
+}
+
+// Endpoint returns the path of a request with its company number and IDs replaced by placeholders,
+// like /company/{company_number}/officers, to group the metrics of a resource
+func Endpoint(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	for i, p := range parts {
+		switch {
+		case i == 1 && parts[0] == "company":
+			parts[i] = "{company_number}"
+		case i > 0 && (parts[i-1] == "document" || isID(p)):
+			parts[i] = "{id}"
+		}
+	}
+	return "/" + strings.Join(parts, "/")
+}
+
+type basePathKey struct{}
+
+// withBasePath stores the path of the API URL in the request, if the request is made to the API's host,
+// so the interceptors can find the endpoint of APIs served under a path prefix, see CustomEnvironment
+func withBasePath(req *http.Request, base *url.URL) *http.Request {
+	if base == nil || req.URL.Host != base.Host {
+		return req
+	}
+	return req.WithContext(context.WithValue(req.Context(), basePathKey{}, strings.TrimRight(base.Path, "/")))
+}
+
+// requestPath returns the path of a request without the path of the API URL
+func requestPath(req *http.Request) string {
+	base, _ := req.Context().Value(basePathKey{}).(string)
+	if base != "" && strings.HasPrefix(req.URL.Path, base+"/") {
+		return req.URL.Path[len(base):]
+	}
+	return req.URL.Path
+}
+
+// pathCompanyNumber returns the company number of a request path, or an empty string
+func pathCompanyNumber(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 2 || parts[0] != "company" {
+		return ""
+	}
+	return parts[1]
+}
+
+// isID returns true if a path segment isn't a lowercase resource name
+func isID(s string) bool {
+	for _, r := range s {
+		if unicode.IsDigit(r) || unicode.IsUpper(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// MetricsInterceptor returns an interceptor which reports the latency, status code, retries and
+// remaining rate limit of every request to m
+func MetricsInterceptor(m Metrics) Interceptor {
+	observe := func(req *http.Request, resp *http.Response) {
+		endpoint := Endpoint(requestPath(req))
+		if start, ok := started(req); ok {
+			m.ObserveLatency(endpoint, time.Since(start))
+		}
+		if attempt, ok := req.Context().Value(retryKey{}).(int); ok && attempt > 0 {
+			m.IncRetry(endpoint)
+		}
+
+		code := 0
+		if resp != nil {
+			code = resp.StatusCode
+			if n, err := strconv.Atoi(resp.Header.Get("X-Ratelimit-Remain")); err == nil {
+				m.SetRateLimitRemaining(n)
+			}
+		}
+		m.IncStatus(endpoint, code)
+	}
+
+	return Interceptor{
+		BeforeRequest: func(req *http.Request) (*http.Request, error) {
+			return withStart(req), nil
+		},
+		AfterResponse: func(req *http.Request, resp *http.Response) error {
+			observe(req, resp)
+			return nil
+		},
+		OnError: func(req *http.Request, resp *http.Response, err error) {
+			observe(req, resp)
+		},
+	}
+}
+
+// DefaultBuckets are the upper bounds in seconds of the latency histogram of a Registry
+var DefaultBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+type histogram struct {
+	counts []uint64 // Per bucket, not cumulative
+	sum    float64
+	count  uint64
+}
+
+type statusKey struct {
+	endpoint string
+	code     int
+}
+
+// Registry keeps the metrics of the API in memory and serves them in the Prometheus text exposition format.
+// The zero value is ready to use. Prefix and Buckets shouldn't change after the first measurement.
+type Registry struct {
+	Prefix  string    // Prefix of the metric names, companies_house if empty
+	Buckets []float64 // Upper bounds of the latency histogram, DefaultBuckets if empty
+
+	mu        sync.Mutex
+	latency   map[string]*histogram
+	status    map[statusKey]uint64
+	retries   map[string]uint64
+	remaining int
+	hasLimit  bool
+	hits      uint64
+	misses    uint64
+}
+
+// NewRegistry returns an empty Registry
+func NewRegistry() *Registry {
+	r := &Registry{}
+	r.init()
+	return r
+}
+
+// init sets the defaults and creates the maps of a zero Registry. The caller holds the lock.
+func (r *Registry) init() {
+	if r.Prefix == "" {
+		r.Prefix = "companies_house"
+	}
+	if len(r.Buckets) == 0 {
+		r.Buckets = DefaultBuckets
+	}
+	if r.latency == nil {
+		r.latency = make(map[string]*histogram)
+		r.status = make(map[statusKey]uint64)
+		r.retries = make(map[string]uint64)
+	}
+}
+
+// ObserveLatency implements the Metrics interface
+func (r *Registry) ObserveLatency(endpoint string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.init()
+
+	h, ok := r.latency[endpoint]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(r.Buckets))}
+		r.latency[endpoint] = h
+	}
+
+	s := d.Seconds()
+	if i := sort.SearchFloat64s(r.Buckets, s); i < len(h.counts) {
+		h.counts[i]++
+	}
+	h.sum += s
+	h.count++
+}
+
+// IncStatus implements the Metrics interface
+func (r *Registry) IncStatus(endpoint string, code int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.init()
+	r.status[statusKey{endpoint, code}]++
+}
+
+// IncRetry implements the Metrics interface
+func (r *Registry) IncRetry(endpoint string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.init()
+	r.retries[endpoint]++
+}
+
+// SetRateLimitRemaining implements the Metrics interface
+func (r *Registry) SetRateLimitRemaining(n int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.remaining, r.hasLimit = n, true
+}
+
+// IncCache implements the Metrics interface
+func (r *Registry) IncCache(hit bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if hit {
+		r.hits++
+	} else {
+		r.misses++
+	}
+}
+
+// CacheHitRatio returns the fraction of the reads from the store which didn't need a request
+func (r *Registry) CacheHitRatio() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.hitRatio()
+}
+
+func (r *Registry) hitRatio() float64 {
+	if r.hits+r.misses == 0 {
+		return 0
+	}
+	return float64(r.hits) / float64(r.hits+r.misses)
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.init()
+
+	var b strings.Builder
+	header := func(name, typ, help string) string {
+		name = r.Prefix + "_" + name
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+		return name
+	}
+
+	name := header("request_duration_seconds", "histogram", "Latency of the requests to the API by endpoint.")
+	endpoints := make([]string, 0, len(r.latency))
+	for endpoint := range r.latency {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	for _, endpoint := range endpoints {
+		h := r.latency[endpoint]
+		var cumulative uint64
+		for i, le := range r.Buckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(&b, "%s_bucket{endpoint=%s,le=\"%s\"} %d\n", name, label(endpoint), formatFloat(le), cumulative)
+		}
+		fmt.Fprintf(&b, "%s_bucket{endpoint=%s,le=\"+Inf\"} %d\n", name, label(endpoint), h.count)
+		fmt.Fprintf(&b, "%s_sum{endpoint=%s} %s\n", name, label(endpoint), formatFloat(h.sum))
+		fmt.Fprintf(&b, "%s_count{endpoint=%s} %d\n", name, label(endpoint), h.count)
+	}
+
+	name = header("responses_total", "counter", "Responses of the API by endpoint and status code, 0 if no response was received.")
+	keys := make([]statusKey, 0, len(r.status))
+	for k := range r.status {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].endpoint != keys[j].endpoint {
+			return keys[i].endpoint < keys[j].endpoint
+		}
+		return keys[i].code < keys[j].code
+	})
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s{endpoint=%s,code=\"%d\"} %d\n", name, label(k.endpoint), k.code, r.status[k])
+	}
+
+	name = header("retries_total", "counter", "Retried requests to the API by endpoint.")
+	endpoints = endpoints[:0]
+	for endpoint := range r.retries {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	for _, endpoint := range endpoints {
+		fmt.Fprintf(&b, "%s{endpoint=%s} %d\n", name, label(endpoint), r.retries[endpoint])
+	}
+
+	if r.hasLimit {
+		name = header("rate_limit_remaining", "gauge", "Remaining requests in the current rate limit window.")
+		fmt.Fprintf(&b, "%s %d\n", name, r.remaining)
+	}
+
+	name = header("cache_requests_total", "counter", "Reads from the local store by result.")
+	fmt.Fprintf(&b, "%s{result=\"hit\"} %d\n%s{result=\"miss\"} %d\n", name, r.hits, name, r.misses)
+	name = header("cache_hit_ratio", "gauge", "Fraction of the reads from the local store which didn't need a request.")
+	fmt.Fprintf(&b, "%s %s\n", name, formatFloat(r.hitRatio()))
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// ServeHTTP serves the metrics in the Prometheus text exposition format
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	r.WriteTo(w)
+}
+
+func label(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
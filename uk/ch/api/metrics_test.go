@@ -0,0 +1,285 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	ch "github.com/appinesshq/globire-go/uk/ch/api"
+	"github.com/appinesshq/globire-go/uk/ch/api/tests"
+)
+
+// memStore keeps resources in memory
+type memStore map[string][]byte
+
+func (s memStore) Load(number string, r ch.Resource, v interface{}) (bool, error) {
+	b, ok := s[number+"/"+string(r)]
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(b, v)
+}
+
+func (s memStore) Save(number string, r ch.Resource, v interface{}) error {
+	b, err := json.Marshal(v)
+	s[number+"/"+string(r)] = b
+	return err
+}
+
+// rateLimitedServer returns the mock server with the rate limit headers of the API
+func rateLimitedServer() *httptest.Server {
+	mock := tests.NewMockServer()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Ratelimit-Remain", "598")
+		mock.Config.Handler.ServeHTTP(w, r)
+	}))
+}
+
+func TestEndpoint(t *testing.T) {
+	cases := map[string]string{
+		"/company/12345678":          "/company/{company_number}",
+		"/company/SC123456/officers": "/company/{company_number}/officers",
+		"/company/12345678/filing-history/MzI3MDk2NjA1M2FkaXF6a2N4": "/company/{company_number}/filing-history/{id}",
+		"/officers/e4-ScyHpxNNUh6ZyV9wnqZS1kfY/appointments":        "/officers/{id}/appointments",
+		"/search/companies": "/search/companies",
+		"/document/abc":     "/document/{id}",
+	}
+
+	for path, expected := range cases {
+		if got := ch.Endpoint(path); got != expected {
+			t.Errorf("expected %q, but got %q", expected, got)
+		}
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	reg := ch.NewRegistry()
+	api, err := ch.New("test", ch.WithMetrics(reg))
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	api.Store = memStore{}
+
+	ts := rateLimitedServer()
+	defer ts.Close()
+	api.URL, err = url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := api.GetCompany("12345678"); err != nil {
+			t.Fatalf("expected to pass, but got: %v", err)
+		}
+	}
+	api.GetCompany("00000000")
+
+	var res ch.Company
+	if err := api.Do(ch.RetryAttempt(context.Background(), 1), http.MethodGet, "/company/87654321", nil, nil, &res); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	if got, expected := reg.CacheHitRatio(), 0.5; got != expected {
+		t.Errorf("expected a cache hit ratio of %v, but got %v", expected, got)
+	}
+
+	rec := httptest.NewRecorder()
+	reg.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	out := rec.Body.String()
+	for _, s := range []string{
+		"# TYPE companies_house_request_duration_seconds histogram",
+		`companies_house_request_duration_seconds_count{endpoint="/company/{company_number}"} 3`,
+		`companies_house_request_duration_seconds_bucket{endpoint="/company/{company_number}",le="+Inf"} 3`,
+		`companies_house_responses_total{endpoint="/company/{company_number}",code="200"} 2`,
+		`companies_house_responses_total{endpoint="/company/{company_number}",code="404"} 1`,
+		`companies_house_retries_total{endpoint="/company/{company_number}"} 1`,
+		"companies_house_rate_limit_remaining 598",
+		`companies_house_cache_requests_total{result="hit"} 2`,
+		`companies_house_cache_requests_total{result="miss"} 2`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected the metrics to contain %q, but got %q", s, out)
+		}
+	}
+}
+
+type span struct {
+	name  string
+	attrs map[string]string
+	err   error
+	ended int
+}
+
+func (s *span) SetAttributes(attrs map[string]string) {
+	for k, v := range attrs {
+		s.attrs[k] = v
+	}
+}
+
+func (s *span) End(err error) {
+	s.err = err
+	s.ended++
+}
+
+type tracer struct {
+	spans []*span
+}
+
+func (t *tracer) Start(ctx context.Context, name string, attrs map[string]string) (context.Context, ch.Span) {
+	s := &span{name: name, attrs: attrs}
+	t.spans = append(t.spans, s)
+	return ctx, s
+}
+
+func TestTracer(t *testing.T) {
+	tr := &tracer{}
+	api, err := ch.New("test", ch.WithTracer(tr))
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	ts := tests.NewMockServer()
+	defer ts.Close()
+	api.URL, err = url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	if _, err := api.GetCompany("12345678"); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	api.OfficerAppointments("unknown")
+
+	// A failing interceptor after the tracer still ends the span once
+	api.Use(ch.Interceptor{AfterResponse: func(req *http.Request, resp *http.Response) error {
+		return errors.New("rejected")
+	}})
+	api.GetCompany("87654321")
+
+	if len(tr.spans) != 3 {
+		t.Fatalf("expected 3 spans, but got %d", len(tr.spans))
+	}
+
+	s := tr.spans[0]
+	if got, expected := s.name, "GET /company/{company_number}"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+	for k, expected := range map[string]string{
+		ch.AttrCompanyNumber:  "12345678",
+		ch.AttrEndpoint:       "/company/{company_number}",
+		ch.AttrHTTPStatusCode: "200",
+	} {
+		if got := s.attrs[k]; got != expected {
+			t.Errorf("expected %q, but got %q", expected, got)
+		}
+	}
+	if s.err != nil || s.ended != 1 {
+		t.Errorf("expected the span to end once without error, but got %d, %v", s.ended, s.err)
+	}
+
+	s = tr.spans[1]
+	if _, ok := s.attrs[ch.AttrCompanyNumber]; ok {
+		t.Errorf("expected no company number, but got %q", s.attrs[ch.AttrCompanyNumber])
+	}
+	if got, expected := s.attrs[ch.AttrHTTPStatusCode], "404"; got != expected || s.err == nil {
+		t.Errorf("expected %q with an error, but got %q, %v", expected, got, s.err)
+	}
+
+	if s = tr.spans[2]; s.err == nil || s.ended != 1 {
+		t.Errorf("expected the span to end once with an error, but got %d, %v", s.ended, s.err)
+	}
+}
+
+func TestMetricsBasePath(t *testing.T) {
+	mock := tests.NewMockServer()
+	defer mock.Close()
+	ts := httptest.NewServer(http.StripPrefix("/ch", mock.Config.Handler))
+	defer ts.Close()
+
+	reg := ch.NewRegistry()
+	tr := &tracer{}
+	api, err := ch.New("test", ch.WithBaseURL(ts.URL+"/ch"), ch.WithMetrics(reg), ch.WithTracer(tr))
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	if _, err := api.GetCompany("12345678"); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	var b bytes.Buffer
+	if _, err := reg.WriteTo(&b); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if s := `endpoint="/company/{company_number}"`; !strings.Contains(b.String(), s) {
+		t.Errorf("expected the metrics to contain %q, but got:\n%s", s, b.String())
+	}
+
+	if len(tr.spans) != 1 {
+		t.Fatalf("expected 1 span, but got %d", len(tr.spans))
+	}
+	if got, expected := tr.spans[0].attrs[ch.AttrCompanyNumber], "12345678"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+}
+
+func TestMetricsRetries(t *testing.T) {
+	var reg ch.Registry // The zero value is ready to use
+	api, err := ch.New("test", ch.WithMetrics(&reg))
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	ts := tests.NewMockServer()
+	defer ts.Close()
+	api.URL, err = url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	// The first attempt isn't a retry, failed retries are counted too
+	for attempt, path := range []string{"/company/12345678", "/company/00000000", "/company/12345678", "/officers/e4-ScyHpxNNUh6ZyV9wnqZS1kfY/appointments"} {
+		var res interface{}
+		api.Do(ch.RetryAttempt(context.Background(), attempt), http.MethodGet, path, nil, nil, &res)
+	}
+	var res interface{}
+	if err := api.Do(context.Background(), http.MethodGet, "/company/87654321", nil, nil, &res); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	var b bytes.Buffer
+	if _, err := reg.WriteTo(&b); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	out := b.String()
+	for _, s := range []string{
+		`companies_house_retries_total{endpoint="/company/{company_number}"} 2`,
+		`companies_house_retries_total{endpoint="/officers/{id}/appointments"} 1`,
+		`companies_house_responses_total{endpoint="/company/{company_number}",code="200"} 3`,
+		`companies_house_responses_total{endpoint="/company/{company_number}",code="404"} 1`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected the metrics to contain %q, but got %q", s, out)
+		}
+	}
+}
+
+func TestRegistryWriteTo(t *testing.T) {
+	reg := &ch.Registry{}
+	var b bytes.Buffer
+	if _, err := reg.WriteTo(&b); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if strings.Contains(b.String(), "rate_limit_remaining") {
+		t.Errorf("expected no rate limit before a response, but got %q", b.String())
+	}
+	if !strings.Contains(b.String(), "companies_house_cache_hit_ratio 0") {
+		t.Errorf("expected a cache hit ratio of 0, but got %q", b.String())
+	}
+}
@@ -0,0 +1,279 @@
+package api
+
+import (
+	"strings"
+	"unicode"
+)
+
+// OfficerName contains the elements of an officer's name, parsed from the "SURNAME, Forenames" format
+// Companies House uses
+type OfficerName struct {
+	Title     string   // e.g. Mr, Dr or Sir
+	Forenames []string // The first forename and any middle names
+	Surname   string   // Proper cased if it was in upper case
+	Honours   []string // e.g. CBE or QC
+	Corporate bool     // The officer is an organisation, whose name is in Surname as provided
+}
+
+var (
+	// titles are recognised as the title of a name, by their lower case form without dots
+	titles = map[string]string{
+		"mr": "Mr", "mrs": "Mrs", "miss": "Miss", "ms": "Ms", "mx": "Mx", "dr": "Dr",
+		"prof": "Prof", "professor": "Professor", "sir": "Sir", "dame": "Dame", "lord": "Lord", "lady": "Lady",
+		"rev": "Rev", "revd": "Revd", "reverend": "Reverend", "hon": "Hon", "the hon": "The Hon",
+		"capt": "Capt", "captain": "Captain", "major": "Major", "col": "Col", "colonel": "Colonel",
+		"baron": "Baron", "baroness": "Baroness", "viscount": "Viscount", "earl": "Earl", "countess": "Countess",
+	}
+
+	// honours are recognised as post-nominal letters
+	honours = map[string]bool{
+		"CBE": true, "OBE": true, "MBE": true, "KBE": true, "DBE": true, "BEM": true, "KCMG": true, "CMG": true,
+		"KC": true, "QC": true, "MP": true, "FRS": true, "FRENG": true, "PHD": true, "JP": true, "DL": true,
+	}
+
+	// forenameTitles are also used as forenames, so they're only a title after the forenames, e.g. "SMITH, John, Earl"
+	forenameTitles = map[string]bool{
+		"Lady": true, "Major": true, "Baron": true, "Earl": true,
+	}
+
+	// legalForms mark the name of an organisation when they're its last word, without dots
+	legalForms = map[string]bool{
+		"LIMITED": true, "LTD": true, "LLP": true, "PLC": true, "LLC": true, "INC": true, "CORP": true,
+		"CORPORATION": true, "COMPANY": true, "CO": true, "GMBH": true, "AG": true, "SA": true, "BV": true,
+		"NV": true, "SARL": true, "LP": true,
+	}
+
+	// corporateWords mark the name of an organisation anywhere in the name
+	corporateWords = map[string]bool{
+		"HOLDINGS": true, "SERVICES": true, "SECRETARIES": true, "SECRETARIAL": true, "NOMINEES": true,
+		"DIRECTORS": true, "REGISTRARS": true, "TRUSTEES": true, "TRUST": true, "PARTNERSHIP": true,
+		"PARTNERS": true, "GROUP": true, "ASSOCIATION": true, "SOCIETY": true, "COUNCIL": true, "&": true,
+	}
+
+	// surnameParticles are kept in lower case when proper casing a surname, unless they're its last word
+	surnameParticles = map[string]bool{
+		"van": true, "von": true, "der": true, "den": true, "de": true, "del": true, "della": true,
+		"da": true, "di": true, "du": true, "la": true, "le": true, "bin": true, "al": true,
+	}
+)
+
+// ParseOfficerName parses a name in the "SURNAME, Title Forenames, Honours" format.
+// Names which end in a legal form like LIMITED, or contain a word like HOLDINGS, are treated as
+// organisations, even if they contain a comma, e.g. "ACME HOLDINGS, INC.". The surname before the
+// first comma isn't checked, so "SA, Lee" is a person.
+func ParseOfficerName(name string) OfficerName {
+	name = strings.Join(strings.Fields(name), " ")
+	rest := name
+	if i := strings.IndexByte(name, ','); i >= 0 {
+		rest = name[i+1:]
+	}
+	if isCorporateName(rest) {
+		return OfficerName{Surname: name, Corporate: true}
+	}
+
+	parts := strings.Split(name, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+
+	if len(parts) == 1 {
+		// A single name, or forenames followed by a surname
+		words := strings.Fields(name)
+		if len(words) == 0 {
+			return OfficerName{}
+		}
+		n := OfficerName{Surname: ProperCase(words[len(words)-1])}
+		n.parseForenames(strings.Join(words[:len(words)-1], " "))
+		return n
+	}
+
+	n := OfficerName{Surname: ProperCase(parts[0])}
+	n.parseForenames(parts[1])
+
+	// Titles and honours which follow the forenames, e.g. "BRANSON, Richard Charles Nicholas, Sir"
+	for _, p := range parts[2:] {
+		if t, ok := title(p); ok {
+			if n.Title == "" {
+				n.Title = t
+			}
+			continue
+		}
+		for _, h := range strings.Fields(p) {
+			n.Honours = append(n.Honours, strings.TrimRight(h, "."))
+		}
+	}
+
+	return n
+}
+
+// parseForenames sets the title, forenames and honours from the part of the name after the surname
+func (n *OfficerName) parseForenames(s string) {
+	words := strings.Fields(s)
+
+	// Titles may consist of two words, e.g. The Hon
+	for len(words) > 0 {
+		if len(words) > 1 {
+			if t, ok := title(words[0] + " " + words[1]); ok {
+				n.Title = t
+				words = words[2:]
+				continue
+			}
+		}
+		t, ok := title(words[0])
+		if !ok || forenameTitles[t] {
+			break
+		}
+		if n.Title == "" {
+			n.Title = t
+		}
+		words = words[1:]
+	}
+
+	for _, w := range words {
+		if honours[strings.ToUpper(strings.TrimRight(w, "."))] && len(n.Forenames) > 0 {
+			n.Honours = append(n.Honours, strings.ToUpper(strings.TrimRight(w, ".")))
+			continue
+		}
+		n.Forenames = append(n.Forenames, w)
+	}
+}
+
+func title(s string) (string, bool) {
+	t, ok := titles[strings.ToLower(strings.ReplaceAll(s, ".", ""))]
+	return t, ok
+}
+
+// isCorporateName returns whether the name ends in a legal form, e.g. "ACME S.A.", or contains a corporate word
+func isCorporateName(name string) bool {
+	words := strings.FieldsFunc(strings.ToUpper(name), func(r rune) bool {
+		return unicode.IsSpace(r) || r == ',' || r == '(' || r == ')'
+	})
+	if len(words) == 0 {
+		return false
+	}
+	if legalForms[strings.ReplaceAll(words[len(words)-1], ".", "")] {
+		return true
+	}
+	for _, w := range words {
+		if corporateWords[strings.Trim(w, ".")] {
+			return true
+		}
+	}
+	return false
+}
+
+// ProperCase returns an upper case name with capitals at the start of every word and after hyphens and apostrophes,
+// e.g. O'BRIEN-MCDONALD becomes O'Brien-McDonald. Names which aren't all upper case are returned as is.
+func ProperCase(name string) string {
+	if name != strings.ToUpper(name) {
+		return name
+	}
+
+	words := strings.Fields(strings.ToLower(name))
+	for i, w := range words {
+		if surnameParticles[w] && i < len(words)-1 {
+			continue
+		}
+		words[i] = properCaseWord(w)
+	}
+	return strings.Join(words, " ")
+}
+
+func properCaseWord(w string) string {
+	var b strings.Builder
+	start := 0
+	for i, r := range w {
+		if r == '-' || r == '\'' {
+			b.WriteString(properCaseSegment(w[start:i]))
+			b.WriteRune(r)
+			start = i + 1
+		}
+	}
+	b.WriteString(properCaseSegment(w[start:]))
+	return b.String()
+}
+
+// properCaseSegment capitalises a part of a name between hyphens and apostrophes.
+// Mc is always followed by a capital; Mac isn't, as in Mackenzie.
+func properCaseSegment(s string) string {
+	r := []rune(s)
+	if len(r) == 0 {
+		return s
+	}
+	r[0] = unicode.ToUpper(r[0])
+	if len(r) > 2 && r[0] == 'M' && r[1] == 'c' {
+		r[2] = unicode.ToUpper(r[2])
+	}
+	return string(r)
+}
+
+// Forename returns the first forename, or an empty string if there are none
+func (n OfficerName) Forename() string {
+	if len(n.Forenames) == 0 {
+		return ""
+	}
+	return n.Forenames[0]
+}
+
+// MiddleNames returns the forenames after the first
+func (n OfficerName) MiddleNames() []string {
+	if len(n.Forenames) < 2 {
+		return nil
+	}
+	return n.Forenames[1:]
+}
+
+// FullName returns the name in natural order, e.g. "Dr Test John Person CBE"
+func (n OfficerName) FullName() string {
+	if n.Corporate {
+		return n.Surname
+	}
+
+	var parts []string
+	if n.Title != "" {
+		parts = append(parts, n.Title)
+	}
+	parts = append(parts, n.Forenames...)
+	parts = append(parts, n.Surname)
+	parts = append(parts, n.Honours...)
+	return strings.Join(parts, " ")
+}
+
+// Salutation returns the name to address a letter to, e.g. "Mr Person" for "Dear Mr Person".
+// Without a title, the first forename is used instead, e.g. "Test Person".
+func (n OfficerName) Salutation() string {
+	switch {
+	case n.Corporate:
+		return n.Surname
+	case n.Title == "Sir" || n.Title == "Dame":
+		// Sir and Dame are used with the forename
+		return strings.TrimSpace(n.Title + " " + n.Forename())
+	case n.Title != "":
+		return n.Title + " " + n.Surname
+	}
+	return strings.TrimSpace(n.Forename() + " " + n.Surname)
+}
+
+// NameElements returns the name in the format of the name elements of persons with significant control
+func (n OfficerName) NameElements() NameElements {
+	return NameElements{
+		Title:          n.Title,
+		Forename:       n.Forename(),
+		OtherForenames: strings.Join(n.MiddleNames(), " "),
+		Surname:        n.Surname,
+	}
+}
+
+// ParsedName returns the officer's name parsed into its elements. Corporate officers are detected by
+// their role or identification, as well as by their name.
+func (o Officer) ParsedName() OfficerName {
+	if o.IsCorporate() {
+		return OfficerName{Surname: o.Name, Corporate: true}
+	}
+	return ParseOfficerName(o.Name)
+}
+
+// ParsedName returns the former name parsed into its elements
+func (f FormerName) ParsedName() OfficerName {
+	return ParseOfficerName(f.Surname + ", " + f.Forenames)
+}
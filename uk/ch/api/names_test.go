@@ -0,0 +1,75 @@
+package api_test
+
+import (
+	"strings"
+	"testing"
+
+	ch "github.com/appinesshq/globire-go/uk/ch/api"
+)
+
+func TestParseOfficerName(t *testing.T) {
+	tt := []struct {
+		name       string
+		full       string
+		salutation string
+		corporate  bool
+	}{
+		{"PERSON, Test", "Test Person", "Test Person", false},
+		{"PERSON, Mr Test John", "Mr Test John Person", "Mr Person", false},
+		{"O'BRIEN-MCDONALD, Dr. Mary", "Dr Mary O'Brien-McDonald", "Dr O'Brien-McDonald", false},
+		{"VAN DER BERG, Jan", "Jan van der Berg", "Jan van der Berg", false},
+		{"BRANSON, Richard Charles Nicholas, Sir", "Sir Richard Charles Nicholas Branson", "Sir Richard", false},
+		{"SMITH, Jane, CBE QC", "Jane Smith CBE QC", "Jane Smith", false},
+		{"MacKenzie, Ian", "Ian MacKenzie", "Ian MacKenzie", false},
+		{"TEST SECRETARIES LIMITED", "TEST SECRETARIES LIMITED", "TEST SECRETARIES LIMITED", true},
+		{"ACME HOLDINGS, INC.", "ACME HOLDINGS, INC.", "ACME HOLDINGS, INC.", true},
+		{"SMITH & CO, LIMITED", "SMITH & CO, LIMITED", "SMITH & CO, LIMITED", true},
+		{"ACME S.A.", "ACME S.A.", "ACME S.A.", true},
+		{"SA, Lee", "Lee Sa", "Lee Sa", false},
+		{"AGNEW, Sarah", "Sarah Agnew", "Sarah Agnew", false},
+		{"SMITH, John, Earl", "Earl John Smith", "Earl Smith", false},
+	}
+	for _, tc := range tt {
+		n := ch.ParseOfficerName(tc.name)
+		if got := n.FullName(); got != tc.full {
+			t.Errorf("expected %q for %q, but got %q", tc.full, tc.name, got)
+		}
+		if got := n.Salutation(); got != tc.salutation {
+			t.Errorf("expected %q for %q, but got %q", tc.salutation, tc.name, got)
+		}
+		if n.Corporate != tc.corporate {
+			t.Errorf("expected corporate %v for %q", tc.corporate, tc.name)
+		}
+	}
+
+	// Titles which are also forenames are only taken as a title after the forenames
+	for _, name := range []string{"SMITH, Earl John", "GRANT, Major Hugh", "STONE, Lady"} {
+		n := ch.ParseOfficerName(name)
+		if n.Title != "" {
+			t.Errorf("expected no title for %q, but got %q", name, n.Title)
+		}
+		if got, expected := n.Forename(), strings.Fields(strings.Split(name, ", ")[1])[0]; got != expected {
+			t.Errorf("expected %q for %q, but got %q", expected, name, got)
+		}
+	}
+
+	n := ch.ParseOfficerName("PERSON, Mr Test John Paul")
+	if got, expected := strings.Join(n.MiddleNames(), " "), "John Paul"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+	if got, expected := n.NameElements().Forename, "Test"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+}
+
+func TestOfficerParsedName(t *testing.T) {
+	o := ch.Officer{Name: "PARENT LTD", OfficerRole: "corporate-director"}
+	if !o.ParsedName().Corporate {
+		t.Errorf("expected a corporate director to be corporate")
+	}
+
+	o = ch.Officer{Name: "PERSON, Test", FormerNames: []ch.FormerName{{Forenames: "Test", Surname: "OLDNAME"}}}
+	if got, expected := o.FormerNames[0].ParsedName().FullName(), "Test Oldname"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+}
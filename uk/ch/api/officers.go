@@ -1,9 +1,7 @@
 package api
 
 import (
-	"context"
 	"fmt"
-	"net/http"
 	"net/url"
 	"strings"
 
@@ -18,6 +16,11 @@ func (f IdentificationType) String() string {
 	return enum.Constants.Get("identification_type", string(f))
 }
 
+// Validate returns an error if the value isn't a known code. An empty value is valid, as the field is optional.
+func (f IdentificationType) Validate() error {
+	return validateOptional(enum.Constants, "identification_type", string(f))
+}
+
 // OfficerRole represents an officer's role
 type OfficerRole string
 
@@ -26,6 +29,11 @@ func (f OfficerRole) String() string {
 	return enum.Constants.Get("officer_role", string(f))
 }
 
+// Validate returns an error if the value isn't a known code
+func (f OfficerRole) Validate() error {
+	return validate(enum.Constants, "officer_role", string(f))
+}
+
 type (
 	// OfficerDateOfBirth struct consists of Day(int), Month (int) and Year (int)
 	OfficerDateOfBirth struct {
@@ -38,54 +46,55 @@ type (
 	Identification struct {
 		IdentificationType IdentificationType `json:"identification_type"`
 		Authority          string             `json:"legal_authority"`
+		CountryRegistered  string             `json:"country_registered"`
 		LegalForm          string             `json:"legal_form"`
 		PlaceRegistered    string             `json:"place_registered"`
 		RegistrationNumber string             `json:"registration_number"`
 	}
 
+	// FormerName contains a name previously used by an officer
+	FormerName struct {
+		Forenames string `json:"forenames"`
+		Surname   string `json:"surname"`
+	}
+
 	// Officers contains the server response of a data request to the companies house API
 	Officers struct {
-		Etag          string    `json:"etag"`
-		Kind          string    `json:"kind"`
-		Start         int       `json:"start_index"`
-		ItemsPerPage  int       `json:"items_per_page"`
-		TotalResults  int       `json:"total_results"`
-		ActiveCount   int       `json:"active_count"`
-		InactiveCount int       `json:"inactive_count"`
-		ResignedCount int       `json:"resigned_count"`
-		Items         []Officer `json:"items"`
-		Links         struct {
-			Self string `json:"self"`
-		} `json:"Links"`
+		Etag          string        `json:"etag"`
+		Kind          string        `json:"kind"`
+		Start         int           `json:"start_index"`
+		ItemsPerPage  int           `json:"items_per_page"`
+		TotalResults  int           `json:"total_results"`
+		ActiveCount   int           `json:"active_count"`
+		InactiveCount int           `json:"inactive_count"`
+		ResignedCount int           `json:"resigned_count"`
+		Items         []Officer     `json:"items"`
+		Links         OfficersLinks `json:"Links"`
 	}
 )
 
 // Officer struct contains the data of a company's officers
 type Officer struct {
 	Address            Address            `json:"address"`
-	AppointedOn        ChDate             `json:"appointed_on"`
+	AppointedOn        ChDate             `json:"appointed_on,omitzero"`
 	CountryOfResidence string             `json:"country_of_residence"`
 	DateOfBirth        OfficerDateOfBirth `json:"date_of_birth"`
-	FormerNames        []struct {
-		Forenames string `json:"forenames"`
-		Surname   string `json:"surname"`
-	} `json:"former_names"`
-	Identification Identification `json:"identification"`
-	Links          struct {
-		Officer struct {
-			Appointments string `json:"appointments"`
-		} `json:"officer"`
-	} `json:"links"`
-	Name        string      `json:"name"`
-	Nationality string      `json:"nationality"`
-	Occupation  string      `json:"occupation"`
-	OfficerRole OfficerRole `json:"officer_role"`
-	ResignedOn  ChDate      `json:"resigned_on"`
+	FormerNames        []FormerName       `json:"former_names"`
+	Identification     Identification     `json:"identification"`
+	Links              OfficerLinks       `json:"links"`
+	Name               string             `json:"name"`
+	Nationality        string             `json:"nationality"`
+	Occupation         string             `json:"occupation"`
+	OfficerRole        OfficerRole        `json:"officer_role"`
+	ResignedOn         ChDate             `json:"resigned_on,omitzero"`
 }
 
-// ID returns an officer's ID
+// ID returns an officer's ID, or an empty string if the link to the officer's appointments is missing or malformed
 func (o Officer) ID() string {
 	a := strings.Split(o.Links.Officer.Appointments, "/")
+	if len(a) < 3 || a[1] != "officers" {
+		return ""
+	}
 	return a[2]
 }
 
@@ -101,7 +110,7 @@ func (c *Company) Officers(options ...Option) (*Officers, error) {
 
 	// Make a call to the service
 	path := fmt.Sprintf("/company/%s/officers", c.CompanyNumber)
-	err := c.api.Do(context.Background(), http.MethodGet, path, params, nil, &res)
+	err := c.api.get(c.CompanyNumber, OfficersResource, path, params, &res)
 	// Ensure to close the ReadCloser
 	// defer b.Close()
 	if err != nil {
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"strings"
+	"time"
+)
+
+// IsActive returns true if the officer hasn't resigned
+func (o Officer) IsActive() bool {
+	return o.ResignedOn.IsZero()
+}
+
+// IsCorporate returns true if the officer is an organisation, by its role or identification
+func (o Officer) IsCorporate() bool {
+	return strings.HasPrefix(string(o.OfficerRole), "corporate-") || o.Identification.IdentificationType != ""
+}
+
+// Tenure returns the time between the appointment and the resignation, or now if the officer is active.
+// Tenure returns 0 if the appointment date is unknown.
+func (o Officer) Tenure(now time.Time) time.Duration {
+	if o.AppointedOn.IsZero() {
+		return 0
+	}
+
+	end := now
+	if !o.IsActive() {
+		end = o.ResignedOn.Time
+	}
+	if end.Before(o.AppointedOn.Time) {
+		return 0
+	}
+	return end.Sub(o.AppointedOn.Time)
+}
+
+// AgeRange returns the youngest and oldest age the officer can have at the time now.
+// Only the month and year of birth are published, so the range is a single age except in the month of birth.
+func (o Officer) AgeRange(now time.Time) (min, max int, ok bool) {
+	dob := o.DateOfBirth
+	if dob.Year == 0 || dob.Month < 1 || dob.Month > 12 {
+		return 0, 0, false
+	}
+
+	age := now.Year() - dob.Year
+	switch {
+	case int(now.Month()) > dob.Month:
+		return age, age, true
+	case int(now.Month()) < dob.Month:
+		return age - 1, age - 1, true
+	}
+	return age - 1, age, true
+}
+
+// Filter returns the officers for which fn returns true.
+// The counts of the result are those of the filtered officers.
+func (o Officers) Filter(fn func(Officer) bool) Officers {
+	res := o
+	res.Items = nil
+	res.ActiveCount, res.InactiveCount, res.ResignedCount = 0, 0, 0
+	for _, officer := range o.Items {
+		if !fn(officer) {
+			continue
+		}
+		res.Items = append(res.Items, officer)
+		if officer.IsActive() {
+			res.ActiveCount++
+		} else {
+			res.ResignedCount++
+		}
+	}
+	res.TotalResults = len(res.Items)
+	return res
+}
+
+// Active returns the officers who haven't resigned
+func (o Officers) Active() Officers {
+	return o.Filter(Officer.IsActive)
+}
+
+// Resigned returns the officers who have resigned
+func (o Officers) Resigned() Officers {
+	return o.Filter(func(officer Officer) bool { return !officer.IsActive() })
+}
+
+// WithRole returns the officers with one of the roles
+func (o Officers) WithRole(roles ...OfficerRole) Officers {
+	return o.Filter(func(officer Officer) bool {
+		for _, r := range roles {
+			if officer.OfficerRole == r {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Corporate returns the officers which are organisations
+func (o Officers) Corporate() Officers {
+	return o.Filter(Officer.IsCorporate)
+}
+
+// NaturalPersons returns the officers which are natural persons
+func (o Officers) NaturalPersons() Officers {
+	return o.Filter(func(officer Officer) bool { return !officer.IsCorporate() })
+}
+
+// AverageTenure returns the average tenure of the officers with a known appointment date
+func (o Officers) AverageTenure(now time.Time) time.Duration {
+	var total time.Duration
+	n := 0
+	for _, officer := range o.Items {
+		if officer.AppointedOn.IsZero() {
+			continue
+		}
+		total += officer.Tenure(now)
+		n++
+	}
+
+	if n == 0 {
+		return 0
+	}
+	return total / time.Duration(n)
+}
+
+// AppointmentsPerYear returns the number of appointments by year
+func (o Officers) AppointmentsPerYear() map[int]int {
+	res := make(map[int]int)
+	for _, officer := range o.Items {
+		if !officer.AppointedOn.IsZero() {
+			res[officer.AppointedOn.Year()]++
+		}
+	}
+	return res
+}
+
+// ResignationsPerYear returns the number of resignations by year
+func (o Officers) ResignationsPerYear() map[int]int {
+	res := make(map[int]int)
+	for _, officer := range o.Items {
+		if !officer.ResignedOn.IsZero() {
+			res[officer.ResignedOn.Year()]++
+		}
+	}
+	return res
+}
+
+// AgeRange returns the youngest and oldest possible ages of the officers with a known date of birth
+func (o Officers) AgeRange(now time.Time) (min, max int, ok bool) {
+	for _, officer := range o.Items {
+		lo, hi, known := officer.AgeRange(now)
+		if !known {
+			continue
+		}
+		if !ok || lo < min {
+			min = lo
+		}
+		if !ok || hi > max {
+			max = hi
+		}
+		ok = true
+	}
+	return min, max, ok
+}
@@ -0,0 +1,63 @@
+package api_test
+
+import (
+	"testing"
+	"time"
+
+	ch "github.com/appinesshq/globire-go/uk/ch/api"
+)
+
+func date(s string) ch.ChDate {
+	t, _ := time.Parse("2006-01-02", s)
+	return ch.ChDate{Time: t}
+}
+
+func TestOfficersAnalytics(t *testing.T) {
+	now := time.Date(2020, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	officers := ch.Officers{Items: []ch.Officer{
+		{Name: "PERSON, Test", OfficerRole: "director", AppointedOn: date("2018-06-15"), DateOfBirth: ch.OfficerDateOfBirth{Month: 6, Year: 1980}},
+		{Name: "OTHER, Test", OfficerRole: "secretary", AppointedOn: date("2016-06-15"), ResignedOn: date("2019-06-15"), DateOfBirth: ch.OfficerDateOfBirth{Month: 1, Year: 1950}},
+		{Name: "PARENT LTD", OfficerRole: "corporate-director", AppointedOn: date("2019-06-15")},
+	}}
+
+	if got, expected := len(officers.Active().Items), 2; got != expected {
+		t.Errorf("expected %d active officers, but got %d", expected, got)
+	}
+	if got, expected := officers.Resigned().ResignedCount, 1; got != expected {
+		t.Errorf("expected %d resigned officers, but got %d", expected, got)
+	}
+	if got, expected := len(officers.WithRole("director", "corporate-director").Items), 2; got != expected {
+		t.Errorf("expected %d directors, but got %d", expected, got)
+	}
+	if got, expected := len(officers.Corporate().Items), 1; got != expected {
+		t.Errorf("expected %d corporate officers, but got %d", expected, got)
+	}
+	if got, expected := len(officers.Active().NaturalPersons().Items), 1; got != expected {
+		t.Errorf("expected %d active natural persons, but got %d", expected, got)
+	}
+
+	// 2 years, 3 years and 1 year
+	day := 24 * time.Hour
+	expected := (731*day + 1095*day + 366*day) / 3
+	if got := officers.AverageTenure(now); got != expected {
+		t.Errorf("expected average tenure %v, but got %v", expected, got)
+	}
+
+	if got := officers.AppointmentsPerYear(); got[2016] != 1 || got[2018] != 1 || got[2019] != 1 {
+		t.Errorf("expected 1 appointment in 2016, 2018 and 2019, but got %v", got)
+	}
+	if got := officers.ResignationsPerYear(); got[2019] != 1 || len(got) != 1 {
+		t.Errorf("expected 1 resignation in 2019, but got %v", got)
+	}
+
+	if min, max, ok := officers.Items[0].AgeRange(now); !ok || min != 39 || max != 40 {
+		t.Errorf("expected an age of 39 to 40, but got %d to %d", min, max)
+	}
+	if min, max, ok := officers.AgeRange(now); !ok || min != 39 || max != 70 {
+		t.Errorf("expected ages of 39 to 70, but got %d to %d", min, max)
+	}
+	if _, _, ok := officers.Items[2].AgeRange(now); ok {
+		t.Errorf("expected an unknown age for a corporate officer")
+	}
+}
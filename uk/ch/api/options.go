@@ -89,3 +89,24 @@ func Category(val ...string) Option {
 		v.Set("category", strings.Join(val, ","))
 	}
 }
+
+// Size limits the amount of results of the alphabetical and dissolved searches
+func Size(val int) Option {
+	return func(v *url.Values) {
+		v.Set("size", strconv.Itoa(val))
+	}
+}
+
+// SearchAbove returns the results before the cursor of an alphabetical search, see OrderedAlphaKeyWithID
+func SearchAbove(cursor string) Option {
+	return func(v *url.Values) {
+		v.Set("search_above", cursor)
+	}
+}
+
+// SearchBelow returns the results after the cursor of an alphabetical search, see OrderedAlphaKeyWithID
+func SearchBelow(cursor string) Option {
+	return func(v *url.Values) {
+		v.Set("search_below", cursor)
+	}
+}
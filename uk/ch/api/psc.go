@@ -0,0 +1,171 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PSCKind represents the kind of a person with significant control
+type PSCKind string
+
+const (
+	// IndividualPSC is a natural person with significant control
+	IndividualPSC PSCKind = "individual-person-with-significant-control"
+
+	// CorporatePSC is a corporate entity with significant control
+	CorporatePSC PSCKind = "corporate-entity-person-with-significant-control"
+
+	// LegalPersonPSC is a legal person with significant control, other than a corporate entity
+	LegalPersonPSC PSCKind = "legal-person-with-significant-control"
+
+	// SuperSecurePSC is a person with significant control whose details are protected
+	SuperSecurePSC PSCKind = "super-secure-person-with-significant-control"
+)
+
+// Validate returns an error if the value isn't one of the known kinds
+func (k PSCKind) Validate() error {
+	switch k {
+	case IndividualPSC, CorporatePSC, LegalPersonPSC, SuperSecurePSC:
+		return nil
+	}
+	return &UnknownValueError{Section: "psc_kind", Value: string(k)}
+}
+
+// NatureOfControl represents the way a person with significant control exercises control,
+// e.g. ownership-of-shares-25-to-50-percent
+type NatureOfControl string
+
+// Ownership returns the band of the share ownership for natures of control like
+// ownership-of-shares-25-to-50-percent, or the right to surplus assets of an LLP.
+// The band runs from more than min up to and including max, as fractions between 0 and 1.
+func (n NatureOfControl) Ownership() (min, max float64, ok bool) {
+	for _, prefix := range []string{"ownership-of-shares-", "right-to-share-surplus-assets-"} {
+		if strings.HasPrefix(string(n), prefix) {
+			return n.band(prefix)
+		}
+	}
+	return 0, 0, false
+}
+
+// VotingRights returns the band of the voting rights for natures of control like
+// voting-rights-25-to-50-percent, as fractions between 0 and 1
+func (n NatureOfControl) VotingRights() (min, max float64, ok bool) {
+	if strings.HasPrefix(string(n), "voting-rights-") {
+		return n.band("voting-rights-")
+	}
+	return 0, 0, false
+}
+
+// band parses the "<min>-to-<max>-percent" part of a nature of control following the prefix
+func (n NatureOfControl) band(prefix string) (float64, float64, bool) {
+	parts := strings.SplitN(strings.TrimPrefix(string(n), prefix), "-", 4)
+	if len(parts) < 4 || parts[1] != "to" || !strings.HasPrefix(parts[3], "percent") {
+		return 0, 0, false
+	}
+
+	min, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	max, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return float64(min) / 100, float64(max) / 100, true
+}
+
+type (
+	// NameElements contains the separate elements of the name of a natural person
+	NameElements struct {
+		Title          string `json:"title"`
+		Forename       string `json:"forename"`
+		OtherForenames string `json:"other_forenames"`
+		MiddleName     string `json:"middle_name"`
+		Surname        string `json:"surname"`
+	}
+
+	// PSC contains the data of a person with significant control
+	PSC struct {
+		Address            Address            `json:"address"`
+		Ceased             bool               `json:"ceased"`
+		CeasedOn           ChDate             `json:"ceased_on,omitzero"`
+		CountryOfResidence string             `json:"country_of_residence"`
+		DateOfBirth        OfficerDateOfBirth `json:"date_of_birth"`
+		Description        string             `json:"description"`
+		Etag               string             `json:"etag"`
+		Identification     Identification     `json:"identification"`
+		Kind               PSCKind            `json:"kind"`
+		Links              PSCLinks           `json:"links"`
+		Name               string             `json:"name"`
+		NameElements       NameElements       `json:"name_elements"`
+		Nationality        string             `json:"nationality"`
+		NaturesOfControl   []NatureOfControl  `json:"natures_of_control"`
+		NotifiedOn         ChDate             `json:"notified_on,omitzero"`
+	}
+
+	// PSCStatement contains a statement of a company about its persons with significant control,
+	// e.g. that it has no registrable person
+	PSCStatement struct {
+		CeasedOn      ChDate            `json:"ceased_on,omitzero"`
+		Etag          string            `json:"etag"`
+		Kind          string            `json:"kind"`
+		LinkedPSCName string            `json:"linked_psc_name"`
+		Links         PSCStatementLinks `json:"links"`
+		NotifiedOn    ChDate            `json:"notified_on,omitzero"`
+		Statement     string            `json:"statement"`
+	}
+
+	// PSCs contains the server response of a request for the persons with significant control of a company
+	PSCs struct {
+		Etag         string    `json:"etag"`
+		Start        int       `json:"start_index"`
+		ItemsPerPage int       `json:"items_per_page"`
+		TotalResults int       `json:"total_results"`
+		ActiveCount  int       `json:"active_count"`
+		CeasedCount  int       `json:"ceased_count"`
+		Items        []PSC     `json:"items"`
+		Links        PSCsLinks `json:"links"`
+	}
+)
+
+// ID returns the ID of the person with significant control
+func (p PSC) ID() string {
+	a := strings.Split(p.Links.Self, "/")
+	return a[len(a)-1]
+}
+
+// Ownership returns the band of the share ownership of the person with significant control.
+// ok is false if none of the natures of control relates to ownership.
+func (p PSC) Ownership() (min, max float64, ok bool) {
+	for _, n := range p.NaturesOfControl {
+		if lo, hi, found := n.Ownership(); found && (!ok || hi > max) {
+			min, max, ok = lo, hi, true
+		}
+	}
+	return
+}
+
+// IsCorporate returns true if the person with significant control is not a natural person
+func (p PSC) IsCorporate() bool {
+	return p.Kind == CorporatePSC || p.Kind == LegalPersonPSC
+}
+
+// PersonsWithSignificantControl gets and returns a company's persons with significant control
+// Possible options: ItemsPerPage, StartIndex, RegisterView
+func (c *Company) PersonsWithSignificantControl(options ...Option) (*PSCs, error) {
+	res := PSCs{}
+	params := url.Values{}
+	for _, option := range options {
+		option(&params)
+	}
+
+	path := fmt.Sprintf("/company/%s/persons-with-significant-control", c.CompanyNumber)
+	if err := c.api.get(c.CompanyNumber, PSCsResource, path, params, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,65 @@
+package api_test
+
+import (
+	"net/url"
+	"testing"
+
+	ch "github.com/appinesshq/globire-go/uk/ch/api"
+	"github.com/appinesshq/globire-go/uk/ch/api/tests"
+)
+
+func TestGetPersonsWithSignificantControl(t *testing.T) {
+	api, err := ch.New("12345")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	ts := tests.NewMockServer()
+	api.URL, err = url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	c, err := api.GetCompany("12345678")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	p, err := c.PersonsWithSignificantControl()
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	if got, expected := p.Items[0].Name, "Mr Test Person"; got != expected {
+		t.Fatalf("expected %q, but got %q", expected, got)
+	}
+
+	if got, expected := p.Items[0].ID(), "AbCdEfGhIjKlMnOpQrStUvWxYz0"; got != expected {
+		t.Fatalf("expected %q, but got %q", expected, got)
+	}
+
+	if got, expected := p.Items[0].IsCorporate(), false; got != expected {
+		t.Fatalf("expected %v, but got %v", expected, got)
+	}
+}
+
+func TestNatureOfControlOwnership(t *testing.T) {
+	tests := []struct {
+		n        ch.NatureOfControl
+		min, max float64
+		ok       bool
+	}{
+		{"ownership-of-shares-25-to-50-percent", 0.25, 0.5, true},
+		{"ownership-of-shares-75-to-100-percent-as-trust", 0.75, 1, true},
+		{"right-to-share-surplus-assets-50-to-75-percent-limited-liability-partnership", 0.5, 0.75, true},
+		{"voting-rights-25-to-50-percent", 0, 0, false},
+		{"right-to-appoint-and-remove-directors", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		min, max, ok := tt.n.Ownership()
+		if min != tt.min || max != tt.max || ok != tt.ok {
+			t.Errorf("expected %v, %v, %v for %q, but got %v, %v, %v", tt.min, tt.max, tt.ok, tt.n, min, max, ok)
+		}
+	}
+}
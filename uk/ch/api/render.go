@@ -0,0 +1,135 @@
+package api
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DescriptionFormat represents an output format for filing descriptions
+type DescriptionFormat int
+
+// Output formats for filing descriptions
+const (
+	PlainText DescriptionFormat = iota
+	HTML
+	Markdown
+)
+
+// descriptionDateLayout is the format in which Companies House shows dates in descriptions
+const descriptionDateLayout = "2 January 2006"
+
+// MissingValuesError is returned when a description template contains placeholders without a value.
+// The placeholders remain in the rendered description.
+type MissingValuesError struct {
+	Description  FilingDescription
+	Placeholders []string
+}
+
+// Error implements the Error interface
+func (err *MissingValuesError) Error() string {
+	return fmt.Sprintf("missing values for %s in description %q", strings.Join(err.Placeholders, ", "), string(err.Description))
+}
+
+// IsMissingValuesError returns true if the provided error is of type MissingValuesError, as well as the asserted error.
+func IsMissingValuesError(err error) (bool, *MissingValuesError) {
+	e, ok := err.(*MissingValuesError)
+	return ok, e
+}
+
+// Render returns the description with the values filled in, in the provided format.
+// Values of date placeholders, such as made_up_date, are formatted as Companies House shows them.
+// Descriptions without a template, such as legacy filings, use the "description" value as text.
+func (f FilingDescription) Render(format DescriptionFormat, values map[string]interface{}) (string, error) {
+	tmpl := f.String()
+	if tmpl == "" {
+		if s, ok := values["description"].(string); ok {
+			return escape(format, s), nil
+		}
+		return escape(format, string(f)), f.Validate()
+	}
+
+	var b strings.Builder
+	var missing []string
+	bold := false
+	for len(tmpl) > 0 {
+		switch {
+		case strings.HasPrefix(tmpl, "**"):
+			bold = !bold
+			switch {
+			case format == HTML && bold:
+				b.WriteString("<strong>")
+			case format == HTML:
+				b.WriteString("</strong>")
+			case format == Markdown:
+				b.WriteString("**")
+			}
+			tmpl = tmpl[2:]
+
+		case tmpl[0] == '{' && strings.IndexByte(tmpl, '}') > 0:
+			end := strings.IndexByte(tmpl, '}')
+			name := tmpl[1:end]
+			tmpl = tmpl[end+1:]
+
+			v, ok := values[name]
+			if !ok || v == nil {
+				missing = append(missing, name)
+				b.WriteString(escape(format, "{"+name+"}"))
+				continue
+			}
+			b.WriteString(escape(format, formatValue(name, v)))
+
+		default:
+			n := strings.IndexAny(tmpl[1:], "*{") + 1
+			if n == 0 {
+				n = len(tmpl)
+			}
+			b.WriteString(escape(format, tmpl[:n]))
+			tmpl = tmpl[n:]
+		}
+	}
+
+	if bold && format == HTML {
+		b.WriteString("</strong>")
+	}
+
+	res := strings.TrimSpace(b.String())
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return res, &MissingValuesError{Description: f, Placeholders: missing}
+	}
+	return res, nil
+}
+
+// RenderDescription returns the filing's description with its values filled in, in the provided format
+func (fl Filing) RenderDescription(format DescriptionFormat) (string, error) {
+	return fl.Description.Render(format, fl.DescriptionValues)
+}
+
+// formatValue returns a value as text, with dates in the Companies House format
+func formatValue(name string, v interface{}) string {
+	s := fmt.Sprint(v)
+	if name == "date" || strings.HasSuffix(name, "_date") {
+		if t, err := time.Parse("2006-01-02", s); err == nil {
+			return t.Format(descriptionDateLayout)
+		}
+	}
+	return s
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`, "#", `\#`,
+)
+
+// escape returns text which is safe to use in the provided format
+func escape(format DescriptionFormat, s string) string {
+	switch format {
+	case HTML:
+		return html.EscapeString(s)
+	case Markdown:
+		return markdownEscaper.Replace(s)
+	}
+	return s
+}
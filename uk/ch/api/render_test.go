@@ -0,0 +1,55 @@
+package api_test
+
+import (
+	"testing"
+
+	ch "github.com/appinesshq/globire-go/uk/ch/api"
+)
+
+func TestRenderDescription(t *testing.T) {
+	desc := ch.FilingDescription("change-person-director-company-with-change-date")
+	values := map[string]interface{}{"officer_name": "Mr John <Test> Smith_Jones", "change_date": "2020-07-01"}
+
+	tt := []struct {
+		format   ch.DescriptionFormat
+		expected string
+	}{
+		{ch.PlainText, "Director's details changed for Mr John <Test> Smith_Jones on 1 July 2020"},
+		{ch.HTML, "<strong>Director&#39;s details changed</strong> for Mr John &lt;Test&gt; Smith_Jones on 1 July 2020"},
+		{ch.Markdown, `**Director's details changed** for Mr John \<Test\> Smith\_Jones on 1 July 2020`},
+	}
+	for _, tc := range tt {
+		got, err := desc.Render(tc.format, values)
+		if err != nil {
+			t.Fatalf("expected to pass, but got: %v", err)
+		}
+		if got != tc.expected {
+			t.Errorf("expected %q, but got %q", tc.expected, got)
+		}
+	}
+}
+
+func TestRenderDescriptionMissing(t *testing.T) {
+	f := ch.Filing{Description: "accounts-with-accounts-type-full"}
+
+	got, err := f.RenderDescription(ch.PlainText)
+	ok, e := ch.IsMissingValuesError(err)
+	if !ok {
+		t.Fatalf("expected a MissingValuesError, but got: %v", err)
+	}
+	if len(e.Placeholders) != 1 || e.Placeholders[0] != "made_up_date" {
+		t.Errorf("expected [made_up_date], but got %v", e.Placeholders)
+	}
+	if expected := "Full accounts made up to {made_up_date}"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	f = ch.Filing{Description: "legacy", DescriptionValues: map[string]interface{}{"description": "Return made up to 01/01/90"}}
+	got, err = f.RenderDescription(ch.PlainText)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if expected := "Return made up to 01/01/90"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type (
+	// SearchMatches contains the character offsets of the parts of a search result matching the query
+	SearchMatches struct {
+		Title          []int `json:"title"`
+		Snippet        []int `json:"snippet"`
+		AddressSnippet []int `json:"address_snippet"`
+	}
+
+	// CompanySearchResult contains a single company returned by a search
+	CompanySearchResult struct {
+		Address         Address                  `json:"address"`
+		AddressSnippet  string                   `json:"address_snippet"`
+		CompanyNumber   string                   `json:"company_number"`
+		CompanyStatus   CompanyStatus            `json:"company_status"`
+		CompanyType     CompanyType              `json:"company_type"`
+		DateOfCessation ChDate                   `json:"date_of_cessation,omitzero"`
+		DateOfCreation  ChDate                   `json:"date_of_creation,omitzero"`
+		Description     string                   `json:"description"`
+		Kind            string                   `json:"kind"`
+		Links           CompanySearchResultLinks `json:"links"`
+		Matches         SearchMatches            `json:"matches"`
+		Snippet         string                   `json:"snippet"`
+		Title           string                   `json:"title"`
+	}
+
+	// CompanySearch contains the server response of a company search
+	CompanySearch struct {
+		Etag         string                `json:"etag"`
+		Items        []CompanySearchResult `json:"items"`
+		ItemsPerPage int                   `json:"items_per_page"`
+		Kind         string                `json:"kind"`
+		Start        int                   `json:"start_index"`
+		TotalResults int                   `json:"total_results"`
+	}
+
+	// OfficerSearchResult contains a single officer returned by a search
+	OfficerSearchResult struct {
+		Address          Address                  `json:"address"`
+		AddressSnippet   string                   `json:"address_snippet"`
+		AppointmentCount int                      `json:"appointment_count"`
+		DateOfBirth      OfficerDateOfBirth       `json:"date_of_birth"`
+		Description      string                   `json:"description"`
+		Kind             string                   `json:"kind"`
+		Links            OfficerSearchResultLinks `json:"links"`
+		Matches          SearchMatches            `json:"matches"`
+		Snippet          string                   `json:"snippet"`
+		Title            string                   `json:"title"`
+	}
+
+	// OfficerSearch contains the server response of an officer search
+	OfficerSearch struct {
+		Etag         string                `json:"etag"`
+		Items        []OfficerSearchResult `json:"items"`
+		ItemsPerPage int                   `json:"items_per_page"`
+		Kind         string                `json:"kind"`
+		Start        int                   `json:"start_index"`
+		TotalResults int                   `json:"total_results"`
+	}
+)
+
+// ID returns the officer ID of the search result
+func (r OfficerSearchResult) ID() string {
+	a := strings.Split(r.Links.Self, "/")
+	if len(a) < 3 {
+		return ""
+	}
+	return a[2]
+}
+
+// SearchCompanies searches for companies by name or number
+// Possible options: ItemsPerPage, StartIndex
+func (a *API) SearchCompanies(query string, options ...Option) (*CompanySearch, error) {
+	res := CompanySearch{}
+	params := url.Values{}
+	for _, option := range options {
+		option(&params)
+	}
+	params.Set("q", query)
+
+	if err := a.Do(context.Background(), http.MethodGet, "/search/companies", params, nil, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// SearchOfficers searches for officers by name
+// Possible options: ItemsPerPage, StartIndex
+func (a *API) SearchOfficers(query string, options ...Option) (*OfficerSearch, error) {
+	res := OfficerSearch{}
+	params := url.Values{}
+	for _, option := range options {
+		option(&params)
+	}
+	params.Set("q", query)
+
+	if err := a.Do(context.Background(), http.MethodGet, "/search/officers", params, nil, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
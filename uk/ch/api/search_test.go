@@ -0,0 +1,118 @@
+package api_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	ch "github.com/appinesshq/globire-go/uk/ch/api"
+	"github.com/appinesshq/globire-go/uk/ch/api/tests"
+)
+
+func TestSearchCompanies(t *testing.T) {
+	api, err := ch.New("12345")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	ts := tests.NewMockServer()
+	api.URL, err = url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	res, err := api.SearchCompanies("test", ch.ItemsPerPage(20))
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	if got, expected := res.Items[0].CompanyNumber, "12345678"; got != expected {
+		t.Fatalf("expected %q, but got %q", expected, got)
+	}
+}
+
+func TestSearchOfficers(t *testing.T) {
+	api, err := ch.New("12345")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	ts := tests.NewMockServer()
+	api.URL, err = url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	res, err := api.SearchOfficers("person")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	if got, expected := res.Items[0].ID(), "e4-ScyHpxNNUh6ZyV9wnqZS1kfY"; got != expected {
+		t.Fatalf("expected %q, but got %q", expected, got)
+	}
+}
+
+func TestAlphabeticalSearchCompanies(t *testing.T) {
+	ts := tests.NewMockServer()
+	defer ts.Close()
+
+	api, err := ch.New("12345", ch.WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	res, err := api.AlphabeticalSearchCompanies(context.Background(), "TEST LTD", ch.Size(2))
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if got, expected := res.Last(), "TEST:12345678"; got != expected {
+		t.Fatalf("expected %q, but got %q", expected, got)
+	}
+
+	res, err = api.AlphabeticalSearchCompanies(context.Background(), "TEST LTD", ch.Size(2), ch.SearchBelow(res.Last()))
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if len(res.Items) != 1 || res.Items[0].CompanyName != "TESTING LTD" {
+		t.Errorf("expected the next company, but got %+v", res.Items)
+	}
+
+	res, err = api.AlphabeticalSearchCompanies(context.Background(), "TEST LTD", ch.Size(1), ch.SearchAbove(res.First()))
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if len(res.Items) != 1 || res.Items[0].CompanyName != "TEST LTD" {
+		t.Errorf("expected the previous company, but got %+v", res.Items)
+	}
+}
+
+func TestDissolvedSearchCompanies(t *testing.T) {
+	ts := tests.NewMockServer()
+	defer ts.Close()
+
+	api, err := ch.New("12345", ch.WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	res, err := api.DissolvedSearchCompanies(context.Background(), "old test", ch.PreviousNameDissolved)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	c := res.Items[0]
+	if got, expected := c.DateOfCessation.Format("2006-01-02"), "2019-01-15"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+	if got, expected := c.MatchedPreviousCompanyName.Name, "OLD TEST LTD"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+	if len(c.PreviousCompanyNames) != 1 {
+		t.Errorf("expected 1 previous name, but got %d", len(c.PreviousCompanyNames))
+	}
+
+	if _, err := api.DissolvedSearchCompanies(context.Background(), "old test", "fuzzy"); err == nil {
+		t.Errorf("expected an invalid search type to fail")
+	}
+}
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/appinesshq/globire-go/uk/ch/api/enum"
+)
+
+// SICLevel represents a level of the UK SIC 2007 hierarchy
+type SICLevel string
+
+// Levels of the UK SIC 2007 hierarchy, from the top
+const (
+	SICSection  SICLevel = "section"
+	SICDivision SICLevel = "division"
+	SICGroup    SICLevel = "group"
+	SICClass    SICLevel = "class"
+	SICSubclass SICLevel = "subclass"
+)
+
+// dormantSICCode is used by Companies House for dormant companies and doesn't belong to a section
+const dormantSICCode SICCode = "99999"
+
+// SICClassification represents a node in the UK SIC 2007 hierarchy
+type SICClassification struct {
+	Level       SICLevel
+	Code        string // A section letter, or the digits of the code
+	Description string
+}
+
+// sic2007 returns true if the code is a UK SIC 2007 subclass code
+func (f SICCode) sic2007() bool {
+	if len(f) != 5 {
+		return false
+	}
+	for _, r := range f {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Description returns the description of the code without the code itself
+func (f SICCode) Description() string {
+	return enum.Constants.Get("sic_descriptions", string(f))
+}
+
+// Section returns the letter of the section the code belongs to, or an empty string if unknown
+func (f SICCode) Section() string {
+	if !f.sic2007() || f == dormantSICCode {
+		return ""
+	}
+	return enum.SICHierarchy.Get("sic_division_sections", f.Division())
+}
+
+// Division returns the two digit division of the code
+func (f SICCode) Division() string {
+	if !f.sic2007() {
+		return ""
+	}
+	return string(f[:2])
+}
+
+// Group returns the three digit group of the code
+func (f SICCode) Group() string {
+	if !f.sic2007() {
+		return ""
+	}
+	return string(f[:3])
+}
+
+// Class returns the four digit class of the code
+func (f SICCode) Class() string {
+	if !f.sic2007() {
+		return ""
+	}
+	return string(f[:4])
+}
+
+// Parents returns the section, division, group and class of the code, from the top of the hierarchy.
+// Parents returns nil if the code isn't a UK SIC 2007 code, or is the code for dormant companies.
+func (f SICCode) Parents() []SICClassification {
+	if !f.sic2007() || f == dormantSICCode {
+		return nil
+	}
+
+	var res []SICClassification
+	if s := f.Section(); s != "" {
+		res = append(res, SICClassification{Level: SICSection, Code: s, Description: enum.SICHierarchy.Get("sic_sections", s)})
+	}
+
+	return append(res,
+		SICClassification{Level: SICDivision, Code: f.Division(), Description: enum.SICHierarchy.Get("sic_divisions", f.Division())},
+		SICClassification{Level: SICGroup, Code: f.Group(), Description: sicGroupDescription(f.Group())},
+		SICClassification{Level: SICClass, Code: f.Class(), Description: sicClassDescription(f.Class())},
+	)
+}
+
+// sicGroupDescription returns the description of a group. A group with a single class ending
+// with 0 has the same description as its class.
+func sicGroupDescription(group string) string {
+	if d := enum.SICHierarchy.Get("sic_groups", group); d != "" {
+		return d
+	}
+	return sicClassDescription(group + "0")
+}
+
+// sicClassDescription returns the description of a class. A class which isn't divided has a
+// single subclass ending with 0, with the same description.
+func sicClassDescription(class string) string {
+	if d := enum.SICHierarchy.Get("sic_classes", class); d != "" {
+		return d
+	}
+	return enum.Constants.Get("sic_descriptions", class+"0")
+}
+
+// SICSections returns all sections of the UK SIC 2007 hierarchy
+func SICSections() []SICClassification {
+	var res []SICClassification
+	for code, desc := range enum.SICHierarchy["sic_sections"] {
+		res = append(res, SICClassification{Level: SICSection, Code: code, Description: desc})
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].Code < res[j].Code })
+	return res
+}
+
+// SearchSICCodes returns the UK SIC 2007 codes whose description contains all words of the query.
+// A numeric query returns the codes starting with it, e.g. 62 for all codes in the division.
+func SearchSICCodes(query string) []SICCode {
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return nil
+	}
+
+	numeric := len(words) == 1 && strings.Trim(words[0], "0123456789") == ""
+
+	var res []SICCode
+	for code, desc := range enum.Constants["sic_descriptions"] {
+		if !SICCode(code).sic2007() {
+			continue
+		}
+
+		if numeric {
+			if strings.HasPrefix(code, words[0]) {
+				res = append(res, SICCode(code))
+			}
+			continue
+		}
+
+		desc = strings.ToLower(desc)
+		match := true
+		for _, w := range words {
+			if !strings.Contains(desc, w) {
+				match = false
+				break
+			}
+		}
+		if match {
+			res = append(res, SICCode(code))
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res
+}
+
+// GroupBySection returns the companies by the letter of the sections of their SIC codes.
+// A company with codes in several sections is in each of them;
+// companies without a known section are grouped under an empty string.
+func GroupBySection(companies []*Company) map[string][]*Company {
+	res := make(map[string][]*Company)
+	for _, c := range companies {
+		sections := make(map[string]bool)
+		for _, code := range c.SICCodes {
+			sections[code.Section()] = true
+		}
+		if len(sections) == 0 {
+			sections[""] = true
+		}
+
+		for s := range sections {
+			res[s] = append(res[s], c)
+		}
+	}
+	return res
+}
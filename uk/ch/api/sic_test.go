@@ -0,0 +1,109 @@
+package api_test
+
+import (
+	"testing"
+
+	ch "github.com/appinesshq/globire-go/uk/ch/api"
+)
+
+func TestSICHierarchy(t *testing.T) {
+	code := ch.SICCode("62012")
+
+	if got, expected := code.Section(), "J"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	parents := code.Parents()
+	if len(parents) != 4 {
+		t.Fatalf("expected 4 parents, but got %d", len(parents))
+	}
+
+	tt := []struct {
+		level ch.SICLevel
+		code  string
+		desc  string
+	}{
+		{ch.SICSection, "J", "Information and communication"},
+		{ch.SICDivision, "62", "Computer programming, consultancy and related activities"},
+		{ch.SICGroup, "620", "Computer programming, consultancy and related activities"},
+		{ch.SICClass, "6201", "Computer programming activities"},
+	}
+	for i, tc := range tt {
+		p := parents[i]
+		if p.Level != tc.level || p.Code != tc.code || p.Description != tc.desc {
+			t.Errorf("expected %v %q %q, but got %v %q %q", tc.level, tc.code, tc.desc, p.Level, p.Code, p.Description)
+		}
+	}
+
+	// An undivided class has the description of its only subclass
+	if got, expected := ch.SICCode("58290").Parents()[3].Description, ch.SICCode("58290").Description(); got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	// A group with a single class has the description of its class
+	if got, expected := ch.SICCode("64205").Parents()[2].Description, "Activities of holding companies"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	for _, digit := range "0123456789" {
+		for _, code := range ch.SearchSICCodes(string(digit)) {
+			for _, p := range code.Parents() {
+				if p.Description == "" {
+					t.Errorf("expected a description for %v %q of %q", p.Level, p.Code, code)
+				}
+			}
+		}
+	}
+
+	if got := ch.SICCode("99999").Section(); got != "" {
+		t.Errorf("expected no section for dormant companies, but got %q", got)
+	}
+
+	if got := ch.SICCode("99999").Parents(); got != nil {
+		t.Errorf("expected no parents for dormant companies, but got %v", got)
+	}
+
+	if got := ch.SICCode("7499").Parents(); got != nil {
+		t.Errorf("expected no parents for a SIC 2003 code, but got %v", got)
+	}
+
+	if got, expected := len(ch.SICSections()), 21; got != expected {
+		t.Errorf("expected %d sections, but got %d", expected, got)
+	}
+}
+
+func TestSearchSICCodes(t *testing.T) {
+	res := ch.SearchSICCodes("domestic SOFTWARE")
+	if len(res) != 1 || res[0] != "62012" {
+		t.Errorf("expected [62012], but got %v", res)
+	}
+
+	res = ch.SearchSICCodes("620")
+	if len(res) == 0 {
+		t.Fatalf("expected codes in group 620")
+	}
+	for _, code := range res {
+		if code.Group() != "620" {
+			t.Errorf("expected only codes in group 620, but got %q", code)
+		}
+	}
+}
+
+func TestGroupBySection(t *testing.T) {
+	companies := []*ch.Company{
+		{CompanyNumber: "1", SICCodes: []ch.SICCode{"62012", "62020"}},
+		{CompanyNumber: "2", SICCodes: []ch.SICCode{"62012", "01110"}},
+		{CompanyNumber: "3"},
+	}
+
+	groups := ch.GroupBySection(companies)
+	if got, expected := len(groups["J"]), 2; got != expected {
+		t.Errorf("expected %d companies in J, but got %d", expected, got)
+	}
+	if got, expected := len(groups["A"]), 1; got != expected {
+		t.Errorf("expected %d companies in A, but got %d", expected, got)
+	}
+	if got, expected := len(groups[""]), 1; got != expected {
+		t.Errorf("expected %d companies without section, but got %d", expected, got)
+	}
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Resource identifies a type of company data in a Store
+type Resource string
+
+// Resources which can be kept in a Store
+const (
+	CompanyResource       Resource = "company"
+	OfficersResource      Resource = "officers"
+	FilingHistoryResource Resource = "filing-history"
+	ChargesResource       Resource = "charges"
+	PSCsResource          Resource = "persons-with-significant-control"
+)
+
+// Store is a local copy of company data.
+// When API.Store is set, requests without options are read from the store before the network is used,
+// and their responses are saved to the store. See API.Fresh and API.MaxAge to refresh stored resources.
+type Store interface {
+	// Load decodes the stored resource of a company into v and returns false if there is none
+	Load(companyNumber string, r Resource, v interface{}) (bool, error)
+
+	// Save stores the resource of a company, replacing the previous version
+	Save(companyNumber string, r Resource, v interface{}) error
+}
+
+// TimedStore is a Store which knows when resources were saved, so they expire after API.MaxAge
+type TimedStore interface {
+	Store
+
+	// SavedAt returns when the resource of a company was saved, and false if there is none
+	SavedAt(companyNumber string, r Resource) (time.Time, bool, error)
+}
+
+// Fresh returns a copy of the API which requests resources instead of reading them from the Store,
+// but still saves the responses to it. Companies returned by the copy are refreshed the same way.
+func (a *API) Fresh() *API {
+	c := *a
+	c.fresh = true
+	return &c
+}
+
+// load reads a resource from the store, unless it's older than MaxAge
+func (a *API) load(companyNumber string, r Resource, v interface{}) (bool, error) {
+	if ts, ok := a.Store.(TimedStore); ok && a.MaxAge > 0 {
+		saved, ok, err := ts.SavedAt(companyNumber, r)
+		if err != nil || !ok || time.Since(saved) > a.MaxAge {
+			return false, err
+		}
+	}
+	return a.Store.Load(companyNumber, r, v)
+}
+
+// get reads a resource of a company from the store, or requests it from the API and saves it to the store
+func (a *API) get(companyNumber string, r Resource, path string, params url.Values, v interface{}) error {
+	if a == nil {
+		return errors.New("company is not linked to an API")
+	}
+
+	cache := a.Store != nil && len(params) == 0
+	if cache && !a.fresh {
+		ok, err := a.load(companyNumber, r, v)
+		if err != nil {
+			return errors.Wrap(err, "loading from store")
+		}
+		if a.Metrics != nil {
+			a.Metrics.IncCache(ok)
+		}
+		if ok {
+			return nil
+		}
+	}
+
+	if err := a.Do(context.Background(), http.MethodGet, path, params, nil, v); err != nil {
+		return err
+	}
+
+	if cache {
+		if err := a.Store.Save(companyNumber, r, v); err != nil {
+			return errors.Wrap(err, "saving to store")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,232 @@
+package store
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/appinesshq/globire-go/uk/ch/api"
+)
+
+// Query selects stored companies. Empty fields match all companies.
+type Query struct {
+	CompanyNumber string
+	Postcode      string // A full postcode, or its outward code with the start of its inward code, e.g. "TS1 2TS", "TS1 2" or "TS1"
+	SICCode       api.SICCode
+	Status        api.CompanyStatus
+}
+
+// index contains the stored companies by their queryable fields
+type index struct {
+	companies map[string]indexed
+	postcodes map[string]map[string]struct{} // Normalized postcode -> company numbers
+	sicCodes  map[api.SICCode]map[string]struct{}
+	statuses  map[api.CompanyStatus]map[string]struct{}
+}
+
+type indexed struct {
+	postcode string
+	sicCodes []api.SICCode
+	status   api.CompanyStatus
+}
+
+func newIndex() *index {
+	return &index{
+		companies: make(map[string]indexed),
+		postcodes: make(map[string]map[string]struct{}),
+		sicCodes:  make(map[api.SICCode]map[string]struct{}),
+		statuses:  make(map[api.CompanyStatus]map[string]struct{}),
+	}
+}
+
+// normalizePostcode returns the postcode in upper case, with a single space between its outward and inward code
+func normalizePostcode(s string) string {
+	outward, inward := splitPostcode(s)
+	return strings.TrimSpace(outward + " " + inward)
+}
+
+// splitPostcode returns the outward and inward code of a postcode in upper case. Postcodes without
+// a space are split before their last three characters, the length of every inward code, unless
+// they're only four characters long, like an outward code.
+func splitPostcode(s string) (outward, inward string) {
+	fields := strings.Fields(strings.ToUpper(s))
+	switch {
+	case len(fields) == 0:
+		return "", ""
+	case len(fields) > 1:
+		return fields[0], strings.Join(fields[1:], "")
+	case len(fields[0]) <= 4:
+		return fields[0], ""
+	}
+	p := fields[0]
+	return p[:len(p)-3], p[len(p)-3:]
+}
+
+// matchPostcode returns true if the normalized postcode has the outward code of the query, and
+// its inward code starts with the inward code of the query
+func matchPostcode(postcode, outward, inward string) bool {
+	o, i := splitPostcode(postcode)
+	return o == outward && strings.HasPrefix(i, inward)
+}
+
+func (ix *index) add(c *api.Company) {
+	ix.remove(c.CompanyNumber)
+
+	i := indexed{
+		postcode: normalizePostcode(c.RegisteredOfficeAddress.PostalCode),
+		sicCodes: c.SICCodes,
+		status:   c.CompanyStatus,
+	}
+	ix.companies[c.CompanyNumber] = i
+
+	if ix.postcodes[i.postcode] == nil {
+		ix.postcodes[i.postcode] = make(map[string]struct{})
+	}
+	ix.postcodes[i.postcode][c.CompanyNumber] = struct{}{}
+	for _, code := range i.sicCodes {
+		if ix.sicCodes[code] == nil {
+			ix.sicCodes[code] = make(map[string]struct{})
+		}
+		ix.sicCodes[code][c.CompanyNumber] = struct{}{}
+	}
+	if ix.statuses[i.status] == nil {
+		ix.statuses[i.status] = make(map[string]struct{})
+	}
+	ix.statuses[i.status][c.CompanyNumber] = struct{}{}
+}
+
+func (ix *index) remove(number string) {
+	i, ok := ix.companies[number]
+	if !ok {
+		return
+	}
+
+	delete(ix.companies, number)
+	delete(ix.postcodes[i.postcode], number)
+	for _, code := range i.sicCodes {
+		delete(ix.sicCodes[code], number)
+	}
+	delete(ix.statuses[i.status], number)
+}
+
+// match returns the sorted numbers of the companies matching the query
+func (ix *index) match(q Query) []string {
+	postcode := normalizePostcode(q.Postcode)
+	outward, inward := splitPostcode(q.Postcode)
+
+	// Start with the smallest candidate set available
+	candidates := make(map[string]struct{})
+	switch {
+	case q.CompanyNumber != "":
+		if _, ok := ix.companies[q.CompanyNumber]; ok {
+			candidates[q.CompanyNumber] = struct{}{}
+		}
+	case q.SICCode != "":
+		candidates = ix.sicCodes[q.SICCode]
+	case len(inward) == 3 && ix.postcodes[postcode] != nil:
+		// A full postcode; partial ones are matched on their outward code below
+		candidates = ix.postcodes[postcode]
+	case q.Status != "":
+		candidates = ix.statuses[q.Status]
+	default:
+		for number := range ix.companies {
+			candidates[number] = struct{}{}
+		}
+	}
+
+	var numbers []string
+	for number := range candidates {
+		i := ix.companies[number]
+		if postcode != "" && !matchPostcode(i.postcode, outward, inward) {
+			continue
+		}
+		if q.SICCode != "" && !hasSICCode(i.sicCodes, q.SICCode) {
+			continue
+		}
+		if q.Status != "" && i.status != q.Status {
+			continue
+		}
+		numbers = append(numbers, number)
+	}
+
+	sort.Strings(numbers)
+	return numbers
+}
+
+func hasSICCode(codes []api.SICCode, code api.SICCode) bool {
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Companies implements the Store interface. The companies are sorted by company number.
+// Companies returned by a query are not linked to an API; use API.GetCompany with the DB as its store for that.
+func (db *DB) Companies(q Query) ([]*api.Company, error) {
+	db.mu.RLock()
+	numbers := db.index.match(q)
+	db.mu.RUnlock()
+
+	var res []*api.Company
+	for _, number := range numbers {
+		c, err := db.Company(number)
+		if err != nil {
+			return nil, err
+		}
+		if c != nil {
+			res = append(res, c)
+		}
+	}
+	return res, nil
+}
+
+// Company returns a stored company, or nil if it isn't stored
+func (db *DB) Company(companyNumber string) (*api.Company, error) {
+	var c api.Company
+	ok, err := db.Load(companyNumber, api.CompanyResource, &c)
+	if !ok || err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Officers returns the stored officers of a company, or nil if they aren't stored
+func (db *DB) Officers(companyNumber string) (*api.Officers, error) {
+	var o api.Officers
+	ok, err := db.Load(companyNumber, api.OfficersResource, &o)
+	if !ok || err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// FilingHistory returns the stored filing history of a company, or nil if it isn't stored
+func (db *DB) FilingHistory(companyNumber string) (*api.FilingHistory, error) {
+	var f api.FilingHistory
+	ok, err := db.Load(companyNumber, api.FilingHistoryResource, &f)
+	if !ok || err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// Charges returns the stored charges of a company, or nil if they aren't stored
+func (db *DB) Charges(companyNumber string) (*api.Charges, error) {
+	var ch api.Charges
+	ok, err := db.Load(companyNumber, api.ChargesResource, &ch)
+	if !ok || err != nil {
+		return nil, err
+	}
+	return &ch, nil
+}
+
+// PSCs returns the stored persons with significant control of a company, or nil if they aren't stored
+func (db *DB) PSCs(companyNumber string) (*api.PSCs, error) {
+	var p api.PSCs
+	ok, err := db.Load(companyNumber, api.PSCsResource, &p)
+	if !ok || err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
@@ -0,0 +1,331 @@
+// Package store keeps a local mirror of Companies House data in a single file, for offline lookups.
+// A DB can be used as the Store of an api.API, to read through it before making requests.
+package store
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/appinesshq/globire-go/uk/ch/api"
+	"github.com/pkg/errors"
+)
+
+// Store persists company data and allows querying the stored companies
+type Store interface {
+	api.Store
+
+	// Upsert stores the resource of a company, unless the stored version has the same etag,
+	// and returns whether the store was changed
+	Upsert(companyNumber string, r api.Resource, v interface{}) (bool, error)
+
+	// Delete removes all stored resources of a company
+	Delete(companyNumber string) error
+
+	// Companies returns the stored companies matching the query
+	Companies(q Query) ([]*api.Company, error)
+
+	Close() error
+}
+
+// record is a single line in the data file
+type record struct {
+	Key     string          `json:"key"`
+	Etag    string          `json:"etag,omitempty"`
+	Saved   time.Time       `json:"saved,omitzero"`
+	Deleted bool            `json:"deleted,omitempty"`
+	Touched bool            `json:"touched,omitempty"` // Only updates the save time of the latest version
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// entry is the position of the latest version of a key in the data file
+type entry struct {
+	offset int64
+	length int
+	etag   string
+	saved  time.Time
+}
+
+// DB is a Store which appends every change to a single file and keeps an index in memory.
+// Superseded versions remain in the file until Compact is called.
+type DB struct {
+	mu    sync.RWMutex
+	path  string
+	f     *os.File
+	size  int64
+	keys  map[string]entry
+	index *index
+}
+
+// Open opens or creates the data file at path
+func Open(path string) (*DB, error) {
+	db := &DB{path: path}
+	if err := db.open(); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+func (db *DB) open() error {
+	f, err := os.OpenFile(db.path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return errors.Wrap(err, "opening data file")
+	}
+
+	db.f = f
+	db.size = 0
+	db.keys = make(map[string]entry)
+	db.index = newIndex()
+
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			// A partial last line is the result of an interrupted write, which is discarded
+			if err := f.Truncate(db.size); err != nil {
+				return errors.Wrap(err, "truncating data file")
+			}
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "reading data file")
+		}
+
+		var rec record
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return errors.Wrapf(err, "decoding record at offset %d", db.size)
+		}
+		db.apply(rec, db.size, len(line))
+		db.size += int64(len(line))
+	}
+
+	if _, err := f.Seek(db.size, io.SeekStart); err != nil {
+		return errors.Wrap(err, "seeking data file")
+	}
+	return nil
+}
+
+// apply updates the in-memory index with a record written at offset
+func (db *DB) apply(rec record, offset int64, length int) {
+	number, r := splitKey(rec.Key)
+	if rec.Deleted {
+		delete(db.keys, rec.Key)
+		if r == api.CompanyResource {
+			db.index.remove(number)
+		}
+		return
+	}
+	if rec.Touched {
+		if e, ok := db.keys[rec.Key]; ok {
+			e.saved = rec.Saved
+			db.keys[rec.Key] = e
+		}
+		return
+	}
+
+	db.keys[rec.Key] = entry{offset: offset, length: length, etag: rec.Etag, saved: rec.Saved}
+	if r == api.CompanyResource {
+		var c api.Company
+		if err := json.Unmarshal(rec.Data, &c); err == nil {
+			db.index.add(&c)
+		}
+	}
+}
+
+func (db *DB) write(rec record) error {
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return errors.Wrap(err, "encoding record")
+	}
+	b = append(b, '\n')
+
+	if _, err := db.f.Write(b); err != nil {
+		return errors.Wrap(err, "writing record")
+	}
+	db.apply(rec, db.size, len(b))
+	db.size += int64(len(b))
+	return nil
+}
+
+func (db *DB) read(e entry) (*record, error) {
+	b := make([]byte, e.length)
+	if _, err := db.f.ReadAt(b, e.offset); err != nil {
+		return nil, errors.Wrap(err, "reading record")
+	}
+
+	var rec record
+	if err := json.Unmarshal(b, &rec); err != nil {
+		return nil, errors.Wrap(err, "decoding record")
+	}
+	return &rec, nil
+}
+
+// Load implements the api.Store interface
+func (db *DB) Load(companyNumber string, r api.Resource, v interface{}) (bool, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	e, ok := db.keys[key(companyNumber, r)]
+	if !ok {
+		return false, nil
+	}
+
+	rec, err := db.read(e)
+	if err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(rec.Data, v); err != nil {
+		return false, errors.Wrap(err, "decoding data")
+	}
+	return true, nil
+}
+
+// SavedAt implements the api.TimedStore interface. Resources stored by older versions have a zero time.
+func (db *DB) SavedAt(companyNumber string, r api.Resource) (time.Time, bool, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	e, ok := db.keys[key(companyNumber, r)]
+	return e.saved, ok, nil
+}
+
+// Save implements the api.Store interface
+func (db *DB) Save(companyNumber string, r api.Resource, v interface{}) error {
+	_, err := db.Upsert(companyNumber, r, v)
+	return err
+}
+
+// Upsert implements the Store interface
+func (db *DB) Upsert(companyNumber string, r api.Resource, v interface{}) (bool, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return false, errors.Wrap(err, "encoding data")
+	}
+
+	var tag struct {
+		Etag string `json:"etag"`
+	}
+	if err := json.Unmarshal(data, &tag); err != nil {
+		return false, errors.Wrap(err, "decoding etag")
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	// An unchanged version isn't written again, but its new save time is, so SavedAt survives reopening the file
+	k := key(companyNumber, r)
+	if e, ok := db.keys[k]; ok && tag.Etag != "" && e.etag == tag.Etag {
+		return false, db.write(record{Key: k, Etag: tag.Etag, Saved: time.Now(), Touched: true})
+	}
+
+	if err := db.write(record{Key: k, Etag: tag.Etag, Saved: time.Now(), Data: data}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Delete implements the Store interface
+func (db *DB) Delete(companyNumber string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, r := range resources {
+		k := key(companyNumber, r)
+		if _, ok := db.keys[k]; !ok {
+			continue
+		}
+		if err := db.write(record{Key: k, Deleted: true}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Compact rewrites the data file with only the latest version of every key
+func (db *DB) Compact() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	tmp, err := os.Create(filepath.Join(filepath.Dir(db.path), "."+filepath.Base(db.path)+".tmp"))
+	if err != nil {
+		return errors.Wrap(err, "creating temporary file")
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	for _, e := range db.keys {
+		rec, err := db.read(e)
+		if err != nil {
+			tmp.Close()
+			return err
+		}
+
+		// Include the save time of later touch records
+		rec.Saved = e.saved
+		b, err := json.Marshal(rec)
+		if err != nil {
+			tmp.Close()
+			return errors.Wrap(err, "encoding record")
+		}
+		if _, err := w.Write(append(b, '\n')); err != nil {
+			tmp.Close()
+			return errors.Wrap(err, "writing record")
+		}
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "writing temporary file")
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "syncing temporary file")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "closing temporary file")
+	}
+
+	if err := db.f.Close(); err != nil {
+		return errors.Wrap(err, "closing data file")
+	}
+	if err := os.Rename(tmp.Name(), db.path); err != nil {
+		return errors.Wrap(err, "replacing data file")
+	}
+	return db.open()
+}
+
+// Close syncs and closes the data file
+func (db *DB) Close() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.f.Sync(); err != nil {
+		db.f.Close()
+		return errors.Wrap(err, "syncing data file")
+	}
+	return db.f.Close()
+}
+
+var resources = []api.Resource{
+	api.CompanyResource,
+	api.OfficersResource,
+	api.FilingHistoryResource,
+	api.ChargesResource,
+	api.PSCsResource,
+}
+
+func key(companyNumber string, r api.Resource) string {
+	return companyNumber + "/" + string(r)
+}
+
+func splitKey(k string) (string, api.Resource) {
+	i := strings.IndexByte(k, '/')
+	if i < 0 {
+		return k, ""
+	}
+	return k[:i], api.Resource(k[i+1:])
+}
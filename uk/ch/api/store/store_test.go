@@ -0,0 +1,289 @@
+package store_test
+
+import (
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ch "github.com/appinesshq/globire-go/uk/ch/api"
+	"github.com/appinesshq/globire-go/uk/ch/api/store"
+	"github.com/appinesshq/globire-go/uk/ch/api/tests"
+)
+
+func tempDB(t *testing.T) (*store.DB, string) {
+	path := filepath.Join(t.TempDir(), "companies.db")
+	db, err := store.Open(path)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	return db, path
+}
+
+func TestReadThrough(t *testing.T) {
+	db, _ := tempDB(t)
+	defer db.Close()
+
+	api, err := ch.New("12345")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	api.Store = db
+
+	ts := tests.NewMockServer()
+	api.URL, err = url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	c, err := api.GetCompany("12345678")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if _, err := c.Officers(); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	// Offline
+	ts.Close()
+
+	c, err = api.GetCompany("12345678")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if got, expected := c.Name, "TEST LTD"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	o, err := c.Officers()
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if len(o.Items) == 0 {
+		t.Errorf("expected stored officers")
+	}
+
+	if _, err := c.Officers(ch.ItemsPerPage(10)); err == nil {
+		t.Errorf("expected requests with options to bypass the store")
+	}
+
+	if _, err := api.GetCompany("87654321"); err == nil {
+		t.Errorf("expected an error for a company which isn't stored")
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	db, path := tempDB(t)
+
+	api, err := ch.New("12345")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	api.Store = db
+
+	ts := tests.NewMockServer()
+	defer ts.Close()
+	api.URL, err = url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	stale := ch.Company{CompanyNumber: "12345678", Name: "OLD TEST LTD", Etag: "old"}
+	if err := db.Save(stale.CompanyNumber, ch.CompanyResource, &stale); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	// Within the maximum age the stored version is used
+	api.MaxAge = time.Hour
+	c, err := api.GetCompany("12345678")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if got, expected := c.Name, "OLD TEST LTD"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	// Fresh requests the company and saves it
+	c, err = api.Fresh().GetCompany("12345678")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if got, expected := c.Name, "TEST LTD"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+	if err := db.Save(stale.CompanyNumber, ch.CompanyResource, &stale); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	// Expired resources are requested again
+	time.Sleep(10 * time.Millisecond)
+	api.MaxAge = time.Millisecond
+	if c, err = api.GetCompany("12345678"); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if got, expected := c.Name, "TEST LTD"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	// The save time survives reopening the file
+	saved, ok, err := db.SavedAt("12345678", ch.CompanyResource)
+	if err != nil || !ok {
+		t.Fatalf("expected a save time, but got: %v, %v", ok, err)
+	}
+	db.Close()
+	if db, err = store.Open(path); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	defer db.Close()
+	reopened, _, _ := db.SavedAt("12345678", ch.CompanyResource)
+	if !reopened.Equal(saved) {
+		t.Errorf("expected %v, but got %v", saved, reopened)
+	}
+}
+
+func TestSavedAtUnchanged(t *testing.T) {
+	db, path := tempDB(t)
+
+	c := ch.Company{CompanyNumber: "00000001", Name: "FIRST LTD", Etag: "a"}
+	if err := db.Save(c.CompanyNumber, ch.CompanyResource, &c); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	first, _, _ := db.SavedAt(c.CompanyNumber, ch.CompanyResource)
+
+	time.Sleep(10 * time.Millisecond)
+	changed, err := db.Upsert(c.CompanyNumber, ch.CompanyResource, &c)
+	if err != nil || changed {
+		t.Fatalf("expected an unchanged etag not to change the store, but got %v, %v", changed, err)
+	}
+	saved, _, _ := db.SavedAt(c.CompanyNumber, ch.CompanyResource)
+	if !saved.After(first) {
+		t.Errorf("expected the save time to be updated, but got %v", saved)
+	}
+
+	// The new save time survives reopening and compacting the file
+	for _, step := range []string{"reopen", "compact"} {
+		if step == "compact" {
+			if err := db.Compact(); err != nil {
+				t.Fatalf("expected to pass, but got: %v", err)
+			}
+		}
+		db.Close()
+		if db, err = store.Open(path); err != nil {
+			t.Fatalf("expected to pass, but got: %v", err)
+		}
+
+		got, _, _ := db.SavedAt(c.CompanyNumber, ch.CompanyResource)
+		if !got.Equal(saved) {
+			t.Errorf("%s: expected %v, but got %v", step, saved, got)
+		}
+
+		var stored ch.Company
+		if ok, err := db.Load(c.CompanyNumber, ch.CompanyResource, &stored); !ok || err != nil || stored.Name != c.Name {
+			t.Errorf("%s: expected the stored company, but got %+v, %v", step, stored, err)
+		}
+	}
+	db.Close()
+}
+
+func TestUpsert(t *testing.T) {
+	db, path := tempDB(t)
+
+	c := ch.Company{CompanyNumber: "00000001", Name: "FIRST LTD", Etag: "a", CompanyStatus: "active", SICCodes: []ch.SICCode{"62012"}}
+	c.RegisteredOfficeAddress.PostalCode = "ts1 2ts"
+
+	changed, err := db.Upsert(c.CompanyNumber, ch.CompanyResource, &c)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if !changed {
+		t.Errorf("expected a new company to change the store")
+	}
+
+	changed, err = db.Upsert(c.CompanyNumber, ch.CompanyResource, &c)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if changed {
+		t.Errorf("expected the same etag not to change the store")
+	}
+
+	c.Etag, c.CompanyStatus = "b", "dissolved"
+	if _, err := db.Upsert(c.CompanyNumber, ch.CompanyResource, &c); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	d := ch.Company{CompanyNumber: "00000002", Name: "SECOND LTD", Etag: "c", CompanyStatus: "active", SICCodes: []ch.SICCode{"62012", "58290"}}
+	d.RegisteredOfficeAddress.PostalCode = "TS1 4AB"
+	if err := db.Save(d.CompanyNumber, ch.CompanyResource, &d); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	e := ch.Company{CompanyNumber: "00000003", Name: "THIRD LTD", Etag: "d", CompanyStatus: "active"}
+	e.RegisteredOfficeAddress.PostalCode = "TS12 3AB"
+	if err := db.Save(e.CompanyNumber, ch.CompanyResource, &e); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	if err := db.Compact(); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	db, err = store.Open(path)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	defer db.Close()
+
+	tt := []struct {
+		q        store.Query
+		expected []string
+	}{
+		{store.Query{}, []string{"00000001", "00000002", "00000003"}},
+		{store.Query{CompanyNumber: "00000002"}, []string{"00000002"}},
+		{store.Query{Postcode: "TS12TS"}, []string{"00000001"}},
+		{store.Query{Postcode: "ts1"}, []string{"00000001", "00000002"}},
+		{store.Query{Postcode: "TS1 2"}, []string{"00000001"}},
+		{store.Query{Postcode: "TS12"}, []string{"00000003"}},
+		{store.Query{Postcode: "ts123ab"}, []string{"00000003"}},
+		{store.Query{SICCode: "58290"}, []string{"00000002"}},
+		{store.Query{Status: "dissolved"}, []string{"00000001"}},
+		{store.Query{SICCode: "62012", Status: "active"}, []string{"00000002"}},
+		{store.Query{Status: "liquidation"}, nil},
+		{store.Query{SICCode: "62012", Status: "active", Postcode: "TS12"}, nil},
+	}
+	for _, tc := range tt {
+		res, err := db.Companies(tc.q)
+		if err != nil {
+			t.Fatalf("expected to pass, but got: %v", err)
+		}
+
+		var got []string
+		for _, c := range res {
+			got = append(got, c.CompanyNumber)
+		}
+		if len(got) != len(tc.expected) {
+			t.Errorf("expected %v for %+v, but got %v", tc.expected, tc.q, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.expected[i] {
+				t.Errorf("expected %v for %+v, but got %v", tc.expected, tc.q, got)
+				break
+			}
+		}
+	}
+
+	if err := db.Delete("00000001"); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	c2, err := db.Company("00000001")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if c2 != nil {
+		t.Errorf("expected a deleted company to be gone")
+	}
+}
@@ -0,0 +1,157 @@
+// Package testdata creates and deletes companies in the Companies House sandbox, using its test data generator.
+// The companies can be used as fixtures for end-to-end tests against the sandbox REST API.
+package testdata
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/appinesshq/globire-go/uk/ch/api"
+	"github.com/pkg/errors"
+)
+
+// CompanySpec describes the company to create. Empty fields use the defaults of the generator,
+// which is an active private limited company in England and Wales with one director.
+type CompanySpec struct {
+	Jurisdiction         api.Jurisdiction  `json:"jurisdiction,omitempty"`
+	CompanyStatus        api.CompanyStatus `json:"company_status,omitempty"`
+	Type                 api.CompanyType   `json:"type,omitempty"`
+	SubType              string            `json:"sub_type,omitempty"`
+	NumberOfAppointments int               `json:"number_of_appointments,omitempty"`
+	OfficerRoles         []api.OfficerRole `json:"officer_roles,omitempty"`
+	NumberOfPSCs         int               `json:"number_of_psc,omitempty"`
+	HasSuperSecurePSCs   bool              `json:"has_super_secure_pscs,omitempty"`
+	AccountsDueStatus    string            `json:"accounts_due_status,omitempty"`
+}
+
+// TestCompany is a company created by the generator
+type TestCompany struct {
+	CompanyNumber string `json:"company_number"`
+	AuthCode      string `json:"auth_code"` // Required to file for and to delete the company
+	CompanyURI    string `json:"company_uri"`
+
+	// Company is the profile of the company, as returned by the REST API
+	Company *api.Company `json:"-"`
+}
+
+// Client creates and deletes test companies
+type Client struct {
+	API    *api.API
+	Client *http.Client // Defaults to http.DefaultClient
+
+	mu      sync.Mutex
+	created map[string]string // Company number -> auth code
+}
+
+// New returns a Client for the test data generator of the API's environment
+func New(a *api.API) (*Client, error) {
+	if a.TestDataURL == nil {
+		return nil, fmt.Errorf("no test data generator in environment")
+	}
+	return &Client{API: a, created: make(map[string]string)}, nil
+}
+
+// Create creates a company and returns it with its profile
+func (c *Client) Create(ctx context.Context, spec CompanySpec) (*TestCompany, error) {
+	body, err := json.Marshal(spec)
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding company spec")
+	}
+
+	var tc TestCompany
+	if err := c.do(ctx, http.MethodPost, "/test-data/company", body, &tc); err != nil {
+		return nil, errors.Wrap(err, "creating company")
+	}
+
+	c.mu.Lock()
+	c.created[tc.CompanyNumber] = tc.AuthCode
+	c.mu.Unlock()
+
+	if tc.Company, err = c.API.GetCompany(tc.CompanyNumber); err != nil {
+		return &tc, errors.Wrap(err, "getting created company")
+	}
+	return &tc, nil
+}
+
+// Delete deletes a company, using the auth code returned when it was created
+func (c *Client) Delete(ctx context.Context, companyNumber, authCode string) error {
+	body, err := json.Marshal(map[string]string{"auth_code": authCode})
+	if err != nil {
+		return errors.Wrap(err, "encoding auth code")
+	}
+
+	if err := c.do(ctx, http.MethodDelete, "/test-data/company/"+companyNumber, body, nil); err != nil {
+		return errors.Wrapf(err, "deleting company %s", companyNumber)
+	}
+
+	c.mu.Lock()
+	delete(c.created, companyNumber)
+	c.mu.Unlock()
+	return nil
+}
+
+// DeleteAll deletes all companies created by the client which haven't been deleted yet.
+// It continues after failures and returns the first error.
+func (c *Client) DeleteAll(ctx context.Context) error {
+	c.mu.Lock()
+	created := make(map[string]string, len(c.created))
+	for number, code := range c.created {
+		created[number] = code
+	}
+	c.mu.Unlock()
+
+	var first error
+	for number, code := range created {
+		if err := c.Delete(ctx, number, code); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// do makes a request to the generator and decodes the result into v, unless v is nil
+func (c *Client) do(ctx context.Context, method, path string, body []byte, v interface{}) error {
+	u := *c.API.TestDataURL
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "creating request")
+	}
+	req.SetBasicAuth(c.API.Key, "")
+	req.Header.Set("Content-Type", "application/json")
+
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "http request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var reqErr api.RequestError
+		if err := json.NewDecoder(resp.Body).Decode(&reqErr); err != nil || len(reqErr.Errors) == 0 {
+			return fmt.Errorf("%v", resp.Status)
+		}
+		return &reqErr
+	}
+
+	if v == nil {
+		_, err := io.Copy(io.Discard, resp.Body)
+		return err
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return errors.Wrap(err, "decoding response")
+	}
+	return nil
+}
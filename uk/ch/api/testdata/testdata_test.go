@@ -0,0 +1,62 @@
+package testdata_test
+
+import (
+	"context"
+	"testing"
+
+	ch "github.com/appinesshq/globire-go/uk/ch/api"
+	"github.com/appinesshq/globire-go/uk/ch/api/testdata"
+	"github.com/appinesshq/globire-go/uk/ch/api/tests"
+	"github.com/pkg/errors"
+)
+
+func TestCreateDelete(t *testing.T) {
+	ts := tests.NewMockServer()
+	defer ts.Close()
+
+	api, err := ch.New("12345", ch.WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	c, err := testdata.New(api)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	tc, err := c.Create(context.Background(), testdata.CompanySpec{Jurisdiction: "england-wales", NumberOfAppointments: 2})
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	if got, expected := tc.AuthCode, "222222"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+	if tc.Company == nil || tc.Company.Name != "TEST LTD" {
+		t.Errorf("expected the company profile, but got %+v", tc.Company)
+	}
+
+	_, err = c.Create(context.Background(), testdata.CompanySpec{Jurisdiction: "wales"})
+	if ok, e := ch.IsRequestError(errors.Cause(err)); !ok || e.Error() != "invalid jurisdiction" {
+		t.Errorf("expected a request error, but got: %v", err)
+	}
+
+	if err := c.Delete(context.Background(), tc.CompanyNumber, "000000"); err == nil {
+		t.Errorf("expected a wrong auth code to fail")
+	}
+
+	if err := c.DeleteAll(context.Background()); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+}
+
+func TestNewWithoutGenerator(t *testing.T) {
+	api, err := ch.New("12345")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	if _, err := testdata.New(api); err == nil {
+		t.Errorf("expected the live environment not to have a test data generator")
+	}
+}
@@ -1,8 +1,11 @@
 package tests
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 )
 
@@ -102,18 +105,540 @@ const (
 		},
 		"items_per_page": 35
 	  }`
+
+	pscData = `{
+		"total_results": 2,
+		"start_index": 0,
+		"items_per_page": 25,
+		"active_count": 2,
+		"ceased_count": 0,
+		"items": [
+		  {
+			"kind": "individual-person-with-significant-control",
+			"name": "Mr Test Person",
+			"name_elements": {
+			  "title": "Mr",
+			  "forename": "Test",
+			  "surname": "PERSON"
+			},
+			"nationality": "Dutch",
+			"country_of_residence": "Lithuania",
+			"date_of_birth": {
+			  "year": 1977,
+			  "month": 12
+			},
+			"notified_on": "2019-06-25",
+			"natures_of_control": [
+			  "ownership-of-shares-25-to-50-percent",
+			  "voting-rights-25-to-50-percent"
+			],
+			"address": {
+			  "premises": "1",
+			  "postal_code": "TS1 T1N",
+			  "locality": "Test Town",
+			  "country": "United Kingdom",
+			  "address_line_1": "Test Road"
+			},
+			"etag": "9d1b4b0d3b2e0c6f7a8e5c4d3b2a1f0e9d8c7b6a",
+			"links": {
+			  "self": "/company/12345678/persons-with-significant-control/individual/AbCdEfGhIjKlMnOpQrStUvWxYz0"
+			}
+		  },
+		  {
+			"kind": "corporate-entity-person-with-significant-control",
+			"name": "PARENT LTD",
+			"identification": {
+			  "legal_authority": "Companies Act 2006",
+			  "legal_form": "Private Limited Company",
+			  "place_registered": "Companies House",
+			  "country_registered": "England",
+			  "registration_number": "87654321"
+			},
+			"notified_on": "2019-06-25",
+			"natures_of_control": [
+			  "ownership-of-shares-50-to-75-percent",
+			  "voting-rights-50-to-75-percent",
+			  "right-to-appoint-and-remove-directors"
+			],
+			"address": {
+			  "premises": "2",
+			  "postal_code": "TS1 2TS",
+			  "locality": "Test Town",
+			  "country": "United Kingdom",
+			  "address_line_1": "Parent Road"
+			},
+			"etag": "1f2e3d4c5b6a7f8e9d0c1b2a3f4e5d6c7b8a9f0e",
+			"links": {
+			  "self": "/company/12345678/persons-with-significant-control/corporate-entity/ZyXwVuTsRqPoNmLkJiHgFeDcBa9"
+			}
+		  }
+		],
+		"links": {
+		  "self": "/company/12345678/persons-with-significant-control"
+		}
+	  }`
+
+	subsidiaryPSCData = `{
+		"total_results": 2,
+		"start_index": 0,
+		"items_per_page": 25,
+		"active_count": 2,
+		"ceased_count": 0,
+		"items": [
+		  {
+			"kind": "individual-person-with-significant-control",
+			"name": "Mr Test Person",
+			"name_elements": {
+			  "title": "Mr",
+			  "forename": "Test",
+			  "surname": "PERSON"
+			},
+			"nationality": "Dutch",
+			"country_of_residence": "Lithuania",
+			"date_of_birth": {
+			  "year": 1977,
+			  "month": 12
+			},
+			"notified_on": "2019-06-25",
+			"natures_of_control": [
+			  "ownership-of-shares-25-to-50-percent",
+			  "voting-rights-25-to-50-percent"
+			],
+			"address": {
+			  "premises": "1",
+			  "postal_code": "TS1 T1N",
+			  "locality": "Test Town",
+			  "country": "United Kingdom",
+			  "address_line_1": "Test Road"
+			},
+			"etag": "5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f",
+			"links": {
+			  "self": "/company/23456789/persons-with-significant-control/individual/AbCdEfGhIjKlMnOpQrStUvWxYz0"
+			}
+		  },
+		  {
+			"kind": "corporate-entity-person-with-significant-control",
+			"name": "PARENT LTD",
+			"identification": {
+			  "legal_authority": "Companies Act 2006",
+			  "legal_form": "Private Limited Company",
+			  "place_registered": "Companies House",
+			  "country_registered": "England",
+			  "registration_number": "87654321"
+			},
+			"notified_on": "2019-06-25",
+			"natures_of_control": [
+			  "ownership-of-shares-50-to-75-percent",
+			  "voting-rights-50-to-75-percent",
+			  "right-to-appoint-and-remove-directors"
+			],
+			"address": {
+			  "premises": "2",
+			  "postal_code": "TS1 2TS",
+			  "locality": "Test Town",
+			  "country": "United Kingdom",
+			  "address_line_1": "Parent Road"
+			},
+			"etag": "1f2e3d4c5b6a7f8e9d0c1b2a3f4e5d6c7b8a9f0e",
+			"links": {
+			  "self": "/company/23456789/persons-with-significant-control/corporate-entity/ZyXwVuTsRqPoNmLkJiHgFeDcBa9"
+			}
+		  }
+		],
+		"links": {
+		  "self": "/company/23456789/persons-with-significant-control"
+		}
+	  }`
+
+	subsidiaryCompanyData = `{
+		"links": {
+		  "self": "/company/23456789",
+		  "officers": "/company/23456789/officers",
+		  "persons_with_significant_control": "/company/23456789/persons-with-significant-control"
+		},
+		"has_charges": false,
+		"date_of_creation": "2019-06-25",
+		"company_number": "23456789",
+		"company_status": "active",
+		"company_name": "SUBSIDIARY LTD",
+		"registered_office_address": {
+		  "premises": "2",
+		  "address_line_1": "Parent Road",
+		  "locality": "Test Town",
+		  "postal_code": "TS1 2TS",
+		  "country": "United Kingdom"
+		},
+		"sic_codes": [
+		  "62012"
+		],
+		"etag": "0a1b2c3d4e5f60718293a4b5c6d7e8f901234567",
+		"jurisdiction": "england-wales",
+		"type": "ltd",
+		"can_file": true
+	  }`
+
+	subsidiaryOfficerData = `{
+		"total_results": 0,
+		"start_index": 0,
+		"items_per_page": 35,
+		"kind": "officer-list",
+		"items": [],
+		"links": {
+		  "self": "/company/23456789/officers"
+		}
+	  }`
+
+	parentCompanyData = `{
+		"links": {
+		  "self": "/company/87654321",
+		  "officers": "/company/87654321/officers",
+		  "persons_with_significant_control": "/company/87654321/persons-with-significant-control"
+		},
+		"has_charges": false,
+		"date_of_creation": "2018-01-10",
+		"company_number": "87654321",
+		"company_status": "active",
+		"company_name": "PARENT LTD",
+		"registered_office_address": {
+		  "premises": "2",
+		  "address_line_1": "Parent Road",
+		  "locality": "Test Town",
+		  "postal_code": "TS1 2TS",
+		  "country": "United Kingdom"
+		},
+		"sic_codes": [
+		  "70100"
+		],
+		"etag": "c0ffee0dd02caf1c3a54ea40b8672637f664bf49",
+		"jurisdiction": "england-wales",
+		"type": "ltd",
+		"can_file": true
+	  }`
+
+	parentOfficerData = `{
+		"total_results": 1,
+		"resigned_count": 0,
+		"start_index": 0,
+		"inactive_count": 0,
+		"kind": "officer-list",
+		"active_count": 1,
+		"items": [
+		  {
+			"officer_role": "director",
+			"date_of_birth": {
+			  "year": 1977,
+			  "month": 12
+			},
+			"nationality": "Dutch",
+			"name": "PERSON, Test",
+			"appointed_on": "2018-01-10",
+			"country_of_residence": "Lithuania",
+			"occupation": "Company Director",
+			"address": {
+			  "premises": "1",
+			  "postal_code": "TS1 T1N",
+			  "locality": "Test Town",
+			  "country": "United Kingdom",
+			  "address_line_1": "Test Road"
+			},
+			"links": {
+			  "officer": {
+				"appointments": "/officers/e4-ScyHpxNNUh6ZyV9wnqZS1kfY/appointments"
+			  }
+			}
+		  }
+		],
+		"links": {
+		  "self": "/company/87654321/officers"
+		},
+		"items_per_page": 35
+	  }`
+
+	parentPSCData = `{
+		"total_results": 1,
+		"start_index": 0,
+		"items_per_page": 25,
+		"active_count": 1,
+		"ceased_count": 0,
+		"items": [
+		  {
+			"kind": "individual-person-with-significant-control",
+			"name": "Mr Test Person",
+			"name_elements": {
+			  "title": "Mr",
+			  "forename": "Test",
+			  "surname": "PERSON"
+			},
+			"nationality": "Dutch",
+			"country_of_residence": "Lithuania",
+			"date_of_birth": {
+			  "year": 1977,
+			  "month": 12
+			},
+			"notified_on": "2018-01-10",
+			"natures_of_control": [
+			  "ownership-of-shares-75-to-100-percent",
+			  "voting-rights-75-to-100-percent",
+			  "right-to-appoint-and-remove-directors"
+			],
+			"address": {
+			  "premises": "1",
+			  "postal_code": "TS1 T1N",
+			  "locality": "Test Town",
+			  "country": "United Kingdom",
+			  "address_line_1": "Test Road"
+			},
+			"links": {
+			  "self": "/company/87654321/persons-with-significant-control/individual/AbCdEfGhIjKlMnOpQrStUvWxYz1"
+			}
+		  }
+		],
+		"links": {
+		  "self": "/company/87654321/persons-with-significant-control"
+		}
+	  }`
+
+	appointmentsData = `{
+		"date_of_birth": {
+		  "year": 1977,
+		  "month": 12
+		},
+		"is_corporate_officer": false,
+		"kind": "personal-appointment",
+		"name": "Test PERSON",
+		"total_results": 2,
+		"start_index": 0,
+		"items_per_page": 35,
+		"items": [
+		  {
+			"appointed_on": "2019-06-25",
+			"appointed_to": {
+			  "company_name": "TEST LTD",
+			  "company_number": "12345678",
+			  "company_status": "active"
+			},
+			"name": "Test PERSON",
+			"name_elements": {
+			  "forename": "Test",
+			  "surname": "PERSON"
+			},
+			"nationality": "Dutch",
+			"occupation": "Company Director",
+			"officer_role": "director",
+			"links": {
+			  "company": "/company/12345678"
+			}
+		  },
+		  {
+			"appointed_on": "2018-01-10",
+			"appointed_to": {
+			  "company_name": "PARENT LTD",
+			  "company_number": "87654321",
+			  "company_status": "active"
+			},
+			"name": "Test PERSON",
+			"name_elements": {
+			  "forename": "Test",
+			  "surname": "PERSON"
+			},
+			"nationality": "Dutch",
+			"occupation": "Company Director",
+			"officer_role": "director",
+			"links": {
+			  "company": "/company/87654321"
+			}
+		  }
+		],
+		"links": {
+		  "self": "/officers/e4-ScyHpxNNUh6ZyV9wnqZS1kfY/appointments"
+		}
+	  }`
+
+	filingHistoryData = `{
+		"etag": "7a6b5c4d3e2f1a0b9c8d7e6f5a4b3c2d1e0f9a8b",
+		"filing_history_status": "filing-history-available",
+		"kind": "filing-history",
+		"start_index": 0,
+		"items_per_page": 25,
+		"total_count": 2,
+		"items": [
+		  {
+			"category": "confirmation-statement",
+			"date": "2020-07-01",
+			"description": "confirmation-statement-with-no-updates",
+			"description_values": {
+			  "made_up_date": "2020-06-24"
+			},
+			"links": {
+			  "self": "/company/12345678/filing-history/MzI3MDk2NjA1M2FkaXF6a2N4",
+			  "document_metadata": "https://frontend-doc-api.companieshouse.gov.uk/document/abc"
+			},
+			"pages": 3,
+			"barcode": "X9A1B2C3",
+			"transaction_id": "MzI3MDk2NjA1M2FkaXF6a2N4",
+			"type": "CS01"
+		  },
+		  {
+			"category": "incorporation",
+			"date": "2019-06-25",
+			"description": "incorporation-company",
+			"links": {
+			  "self": "/company/12345678/filing-history/MzIzODQ2NzQyOWFkaXF6a2N4"
+			},
+			"pages": 12,
+			"barcode": "X8A1B2C3",
+			"transaction_id": "MzIzODQ2NzQyOWFkaXF6a2N4",
+			"type": "NEWINC"
+		  }
+		]
+	  }`
+
+	documentMetadataData = `{
+		"company_number": "12345678",
+		"barcode": "X9A1B2C3",
+		"category": "confirmation-statement",
+		"pages": 3,
+		"significant_date": "2020-06-24",
+		"significant_date_type": "made-up-date",
+		"etag": "",
+		"links": {
+		  "self": "https://frontend-doc-api.companieshouse.gov.uk/document/abc",
+		  "document": "https://frontend-doc-api.companieshouse.gov.uk/document/abc/content"
+		},
+		"resources": {
+		  "application/pdf": {
+			"content_length": 13
+		  }
+		}
+	  }`
+
+	companySearchData = `{
+		"kind": "search#companies",
+		"start_index": 0,
+		"items_per_page": 20,
+		"total_results": 2,
+		"items": [
+		  {
+			"kind": "searchresults#company",
+			"title": "TEST LTD",
+			"company_number": "12345678",
+			"company_status": "active",
+			"company_type": "ltd",
+			"date_of_creation": "2019-06-25",
+			"address_snippet": "Office 1, 15 Test Road, Test Town, TS1 2TS",
+			"description": "12345678 - Incorporated on 25 June 2019",
+			"links": {
+			  "self": "/company/12345678"
+			}
+		  },
+		  {
+			"kind": "searchresults#company",
+			"title": "PARENT LTD",
+			"company_number": "87654321",
+			"company_status": "active",
+			"company_type": "ltd",
+			"date_of_creation": "2018-01-10",
+			"address_snippet": "2 Parent Road, Test Town, TS1 2TS",
+			"description": "87654321 - Incorporated on 10 January 2018",
+			"links": {
+			  "self": "/company/87654321"
+			}
+		  }
+		]
+	  }`
+
+	officerSearchData = `{
+		"kind": "search#officers",
+		"start_index": 0,
+		"items_per_page": 20,
+		"total_results": 1,
+		"items": [
+		  {
+			"kind": "searchresults#officer",
+			"title": "Test PERSON",
+			"appointment_count": 2,
+			"date_of_birth": {
+			  "year": 1977,
+			  "month": 12
+			},
+			"address_snippet": "1 Test Road, Test Town, TS1 T1N",
+			"description": "Total number of appointments 2 - Born December 1977",
+			"links": {
+			  "self": "/officers/e4-ScyHpxNNUh6ZyV9wnqZS1kfY/appointments"
+			}
+		  }
+		]
+	  }`
+
+	chargesData = `{
+		"etag": "5e4d3c2b1a0f9e8d7c6b5a4f3e2d1c0b9a8f7e6d",
+		"total_count": 1,
+		"unfiltered_count": 1,
+		"satisfied_count": 0,
+		"part_satisfied_count": 0,
+		"items": [
+		  {
+			"etag": "0a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b",
+			"id": "Zm9vYmFyYmF6cXV4",
+			"charge_code": "123456780001",
+			"charge_number": 1,
+			"status": "outstanding",
+			"classification": {
+			  "type": "charge-description",
+			  "description": "A registered charge"
+			},
+			"created_on": "2020-01-15",
+			"delivered_on": "2020-01-20",
+			"particulars": {
+			  "contains_fixed_charge": true,
+			  "contains_negative_pledge": true
+			},
+			"persons_entitled": [
+			  {
+				"name": "Test Bank PLC"
+			  }
+			],
+			"links": {
+			  "self": "/company/12345678/charges/Zm9vYmFyYmF6cXV4"
+			}
+		  }
+		]
+	  }`
 )
 
 // NewMockServer simulates the API for testing purposes.
 // Supported requests:
-// 12345678 - Active Limited company
-// Other company numbers - Not found error
+// 12345678 - Active Limited company, with officers, persons with significant control and charges
+// 23456789 - Active Limited company without officers, owned by 87654321 and the individual PSC of 87654321
+// 87654321 - Active Limited company, which is the corporate PSC of 12345678 and 23456789
+// e4-ScyHpxNNUh6ZyV9wnqZS1kfY - Officer appointed to both companies
+// search/companies, search/officers - Both companies and the officer, regardless of the query
+// advanced-search/companies - Both companies regardless of the filters, paged by size and start_index
+// alphabetical-search/companies - Three companies in alphabetical order, paged by search_above, search_below and size
+// dissolved-search/companies - A dissolved company with a previous name, for any valid search_type
+// document/abc - Metadata of the document of the confirmation statement of 12345678, with its content as application/pdf
+// test-data/company - Creates 12345678 with auth code 222222, which can be deleted with that code
+// Other company numbers and officer IDs - Not found error
 func NewMockServer() *httptest.Server {
 	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		path := strings.Split(r.URL.Path[1:], "/")
 		switch path[0] {
 		case "company":
 			getCompany(w, path)
+		case "officers":
+			getOfficer(w, path)
+		case "search":
+			search(w, path)
+		case "advanced-search":
+			advancedSearch(w, r, path)
+		case "alphabetical-search":
+			alphabeticalSearch(w, r, path)
+		case "dissolved-search":
+			dissolvedSearch(w, r, path)
+		case "test-data":
+			testData(w, r, path)
+		case "document":
+			getDocument(w, r, path)
 		default:
 			w.WriteHeader(http.StatusBadRequest)
 			w.Write([]byte("Invalid request path"))
@@ -121,20 +646,254 @@ func NewMockServer() *httptest.Server {
 	}))
 }
 
+// companies maps company numbers to the data returned for the company and its sub-resources
+var companies = map[string]map[string]string{
+	"12345678": {
+		"":                                 companyData,
+		"officers":                         officerData,
+		"persons-with-significant-control": pscData,
+		"charges":                          chargesData,
+		"filing-history":                   filingHistoryData,
+	},
+	"23456789": {
+		"":                                 subsidiaryCompanyData,
+		"officers":                         subsidiaryOfficerData,
+		"persons-with-significant-control": subsidiaryPSCData,
+	},
+	"87654321": {
+		"":                                 parentCompanyData,
+		"officers":                         parentOfficerData,
+		"persons-with-significant-control": parentPSCData,
+	},
+}
+
 func getCompany(w http.ResponseWriter, path []string) {
+	if len(path) < 2 {
+		notFound(w)
+		return
+	}
+
+	resource := ""
+	if len(path) > 2 {
+		resource = path[2]
+	}
+
+	data, ok := companies[path[1]][resource]
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(data))
+}
+
+func getOfficer(w http.ResponseWriter, path []string) {
 	switch {
-	case len(path) >= 2 && path[1] == "12345678":
-		switch {
-		case len(path) > 2 && path[2] == "officers":
-			w.WriteHeader(http.StatusOK)
-			w.Write([]byte(officerData))
-		default:
-			w.WriteHeader(http.StatusOK)
-			w.Write([]byte(companyData))
+	case len(path) > 2 && path[1] == "e4-ScyHpxNNUh6ZyV9wnqZS1kfY" && path[2] == "appointments":
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(appointmentsData))
+	default:
+		notFound(w)
+	}
+}
+
+// search returns the same results for any query
+func search(w http.ResponseWriter, path []string) {
+	switch {
+	case len(path) > 1 && path[1] == "companies":
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(companySearchData))
+	case len(path) > 1 && path[1] == "officers":
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(officerSearchData))
+	default:
+		notFound(w)
+	}
+}
+
+// advancedSearchResults are returned by the advanced search for any filters
+var advancedSearchResults = []string{
+	`{
+		"company_name": "TEST LTD",
+		"company_number": "12345678",
+		"company_status": "active",
+		"company_type": "ltd",
+		"date_of_creation": "2019-06-25",
+		"kind": "search-results#company",
+		"links": {"company_profile": "/company/12345678"},
+		"registered_office_address": {"address_line_1": "Office 1", "locality": "Test Town", "postal_code": "TS1 2TS"},
+		"sic_codes": ["58290", "62012"]
+	}`,
+	`{
+		"company_name": "PARENT LTD",
+		"company_number": "87654321",
+		"company_status": "active",
+		"company_type": "ltd",
+		"date_of_creation": "2015-03-02",
+		"kind": "search-results#company",
+		"links": {"company_profile": "/company/87654321"},
+		"registered_office_address": {"address_line_1": "1 Parent Street", "locality": "Test Town", "postal_code": "TS1 1AA"},
+		"sic_codes": ["70100"]
+	}`,
+}
+
+func advancedSearch(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) < 2 || path[1] != "companies" {
+		notFound(w)
+		return
+	}
+
+	start, _ := strconv.Atoi(r.URL.Query().Get("start_index"))
+	size, err := strconv.Atoi(r.URL.Query().Get("size"))
+	if err != nil {
+		size = 20
+	}
+
+	items := advancedSearchResults
+	if start > len(items) {
+		start = len(items)
+	}
+	items = items[start:]
+	if size < len(items) {
+		items = items[:size]
+	}
+
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, `{"hits": %d, "kind": "search#advanced-search", "items": [%s]}`, len(advancedSearchResults), strings.Join(items, ","))
+}
+
+// alphabeticalIndex is the part of the alphabetical index returned by the alphabetical search
+var alphabeticalIndex = []struct{ key, item string }{
+	{"PARENT:87654321", `{"company_name": "PARENT LTD", "company_number": "87654321", "company_status": "active", "company_type": "ltd", "kind": "search-results#alphabetical-search", "links": {"company_profile": "/company/87654321"}, "ordered_alpha_key_with_id": "PARENT:87654321"}`},
+	{"TEST:12345678", `{"company_name": "TEST LTD", "company_number": "12345678", "company_status": "active", "company_type": "ltd", "kind": "search-results#alphabetical-search", "links": {"company_profile": "/company/12345678"}, "ordered_alpha_key_with_id": "TEST:12345678"}`},
+	{"TESTING:11111111", `{"company_name": "TESTING LTD", "company_number": "11111111", "company_status": "dissolved", "company_type": "ltd", "kind": "search-results#alphabetical-search", "links": {"company_profile": "/company/11111111"}, "ordered_alpha_key_with_id": "TESTING:11111111"}`},
+}
+
+func alphabeticalSearch(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) < 2 || path[1] != "companies" {
+		notFound(w)
+		return
+	}
+
+	q := r.URL.Query()
+	size, err := strconv.Atoi(q.Get("size"))
+	if err != nil {
+		size = 20
+	}
+
+	var items []string
+	for _, e := range alphabeticalIndex {
+		if above := q.Get("search_above"); above != "" && e.key >= above {
+			continue
 		}
+		if below := q.Get("search_below"); below != "" && e.key <= below {
+			continue
+		}
+		items = append(items, e.item)
+	}
+	if size < len(items) {
+		if q.Get("search_above") != "" {
+			items = items[len(items)-size:]
+		} else {
+			items = items[:size]
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, `{"kind": "search#alphabetical-search", "items": [%s]}`, strings.Join(items, ","))
+}
+
+const dissolvedSearchData = `{
+	"hits": 1,
+	"kind": "search#dissolved",
+	"items": [
+	  {
+		"company_name": "TESTING LTD",
+		"company_number": "11111111",
+		"company_status": "dissolved",
+		"date_of_cessation": "2019-01-15",
+		"date_of_creation": "2010-05-04",
+		"kind": "searchresults#dissolved-company",
+		"ordered_alpha_key_with_id": "TESTING:11111111",
+		"matched_previous_company_name": {"name": "OLD TEST LTD", "effective_from": "2010-05-04", "ceased_on": "2012-01-01"},
+		"previous_company_names": [
+		  {"name": "OLD TEST LTD", "effective_from": "2010-05-04", "ceased_on": "2012-01-01"}
+		],
+		"registered_office_address": {"address_line_1": "2 Test Road", "locality": "Test Town", "postal_code": "TS1 3TS"}
+	  }
+	]
+  }`
+
+func dissolvedSearch(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) < 2 || path[1] != "companies" {
+		notFound(w)
+		return
+	}
 
+	switch r.URL.Query().Get("search_type") {
+	case "best-match", "previous-name-dissolved", "alphabetical":
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(dissolvedSearchData))
 	default:
-		w.WriteHeader(http.StatusNotFound)
-		w.Write([]byte("Not found"))
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errors":[{"error":"invalid search type","location":"search_type","type":"ch:validation"}]}`))
 	}
 }
+
+// getDocument simulates the document API
+func getDocument(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 2 && path[1] == "abc":
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(documentMetadataData))
+	case len(path) == 3 && path[1] == "abc" && path[2] == "content":
+		if r.Header.Get("Accept") != "application/pdf" {
+			w.WriteHeader(http.StatusNotAcceptable)
+			w.Write([]byte("Not acceptable"))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("%PDF-1.4 test"))
+	default:
+		notFound(w)
+	}
+}
+
+// testData simulates the sandbox test data generator
+func testData(w http.ResponseWriter, r *http.Request, path []string) {
+	var body struct {
+		Jurisdiction string `json:"jurisdiction"`
+		AuthCode     string `json:"auth_code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errors":[{"error":"invalid body","type":"ch:validation"}]}`))
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodPost && len(path) == 2 && path[1] == "company":
+		if body.Jurisdiction != "" && body.Jurisdiction != "england-wales" && body.Jurisdiction != "scotland" && body.Jurisdiction != "northern-ireland" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors":[{"error":"invalid jurisdiction","location":"jurisdiction","type":"ch:validation"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"company_number":"12345678","auth_code":"222222","company_uri":"/company/12345678"}`))
+	case r.Method == http.MethodDelete && len(path) == 3 && path[1] == "company" && path[2] == "12345678":
+		if body.AuthCode != "222222" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"errors":[{"error":"invalid auth code","type":"ch:service"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		notFound(w)
+	}
+}
+
+func notFound(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNotFound)
+	w.Write([]byte("Not found"))
+}
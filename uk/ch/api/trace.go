@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// Attribute names of the spans started around requests, following the OpenTelemetry conventions
+const (
+	AttrHTTPMethod     = "http.request.method"
+	AttrHTTPStatusCode = "http.response.status_code"
+	AttrURLPath        = "url.path"
+	AttrEndpoint       = "companies_house.endpoint"
+	AttrCompanyNumber  = "companies_house.company_number"
+)
+
+// Tracer starts spans around requests. It can be implemented by an adapter to OpenTelemetry
+// or another tracing system.
+type Tracer interface {
+	Start(ctx context.Context, name string, attrs map[string]string) (context.Context, Span)
+}
+
+// Span represents the work of a single request
+type Span interface {
+	SetAttributes(attrs map[string]string)
+	End(err error) // err is nil if the request succeeded
+}
+
+// WithTracer starts a span with t around every request of the API.
+// Spans only have a parent for requests made through Do and DoRequest with a context carrying
+// the parent span; the other methods of the API use context.Background.
+func WithTracer(t Tracer) ClientOption {
+	return func(a *API) error {
+		a.Use(TraceInterceptor(t))
+		return nil
+	}
+}
+
+type spanKey struct{}
+
+// TraceInterceptor returns an interceptor which starts a span around every request, named after
+// its method and endpoint and carrying the company number if the request is about a company
+func TraceInterceptor(t Tracer) Interceptor {
+	end := func(req *http.Request, resp *http.Response, err error) {
+		span, ok := req.Context().Value(spanKey{}).(Span)
+		if !ok {
+			return
+		}
+		if resp != nil {
+			span.SetAttributes(map[string]string{AttrHTTPStatusCode: strconv.Itoa(resp.StatusCode)})
+		}
+		span.End(err)
+	}
+
+	return Interceptor{
+		BeforeRequest: func(req *http.Request) (*http.Request, error) {
+			path := requestPath(req)
+			endpoint := Endpoint(path)
+			attrs := map[string]string{
+				AttrHTTPMethod: req.Method,
+				AttrURLPath:    req.URL.Path,
+				AttrEndpoint:   endpoint,
+			}
+			if number := pathCompanyNumber(path); number != "" {
+				attrs[AttrCompanyNumber] = number
+			}
+
+			ctx, span := t.Start(req.Context(), req.Method+" "+endpoint, attrs)
+			return req.WithContext(context.WithValue(ctx, spanKey{}, span)), nil
+		},
+		AfterResponse: func(req *http.Request, resp *http.Response) error {
+			end(req, resp, nil)
+			return nil
+		},
+		OnError: end,
+	}
+}
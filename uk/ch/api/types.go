@@ -1,11 +1,19 @@
 package api
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
 )
 
+const (
+	chDateLayout      = "2006-01-02"
+	dateOfBirthLayout = "2006-01-02T15:04:00"
+)
+
 // Address struct contains the details of addresses
 type Address struct {
 	Etag         string `json:"etag"`
@@ -21,6 +29,8 @@ type Address struct {
 }
 
 // ChDate is type which supports unmarshalling from CH json response to a Go time type
+// A zero ChDate marshals to null, so it needs no omitzero tag. Fields are tagged omitzero anyway,
+// which omits them instead when built with Go 1.24 or later.
 type ChDate struct {
 	time.Time
 }
@@ -28,13 +38,52 @@ type ChDate struct {
 // UnmarshalJSON implements the unmarshalling functionality
 func (cd *ChDate) UnmarshalJSON(b []byte) (err error) {
 	s := strings.Trim(string(b), "\"")
-	if len(s) == 0 {
+	if len(s) == 0 || s == "null" {
 		return
 	}
-	cd.Time, err = time.Parse("2006-01-02", s)
+	cd.Time, err = time.Parse(chDateLayout, s)
 	return
 }
 
+// MarshalJSON implements the json.Marshaler interface using the CH date format
+func (cd ChDate) MarshalJSON() ([]byte, error) {
+	if cd.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(cd.Format(chDateLayout))
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface
+func (cd *ChDate) UnmarshalText(b []byte) (err error) {
+	return cd.UnmarshalJSON(b)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface
+func (cd ChDate) MarshalText() ([]byte, error) {
+	if cd.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(cd.Format(chDateLayout)), nil
+}
+
+// Scan implements the sql.Scanner interface
+func (cd *ChDate) Scan(src interface{}) error {
+	t, err := scanTime(src, chDateLayout)
+	if err != nil {
+		return err
+	}
+	cd.Time = t
+	return nil
+}
+
+// Value implements the driver.Valuer interface. A zero date is stored as NULL.
+func (cd ChDate) Value() (driver.Value, error) {
+	if cd.IsZero() {
+		return nil, nil
+	}
+	return cd.Time, nil
+}
+
 // DateOfBirth is a type which supports unmarshalling from CH json response to a Go time type
 type DateOfBirth struct {
 	time.Time
@@ -43,10 +92,74 @@ type DateOfBirth struct {
 // UnmarshalJSON implements the unmarshalling functionality
 func (dob *DateOfBirth) UnmarshalJSON(b []byte) (err error) {
 	s := strings.Trim(string(b), "\"")
-	dob.Time, err = time.Parse("2006-01-02T15:04:00", s)
+	if len(s) == 0 || s == "null" {
+		return
+	}
+	dob.Time, err = time.Parse(dateOfBirthLayout, s)
 	return
 }
 
+// MarshalJSON implements the json.Marshaler interface using the CH date of birth format
+func (dob DateOfBirth) MarshalJSON() ([]byte, error) {
+	if dob.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(dob.Format(dateOfBirthLayout))
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface
+func (dob *DateOfBirth) UnmarshalText(b []byte) error {
+	return dob.UnmarshalJSON(b)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface
+func (dob DateOfBirth) MarshalText() ([]byte, error) {
+	if dob.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(dob.Format(dateOfBirthLayout)), nil
+}
+
+// Scan implements the sql.Scanner interface
+func (dob *DateOfBirth) Scan(src interface{}) error {
+	t, err := scanTime(src, dateOfBirthLayout)
+	if err != nil {
+		return err
+	}
+	dob.Time = t
+	return nil
+}
+
+// Value implements the driver.Valuer interface. A zero date of birth is stored as NULL.
+func (dob DateOfBirth) Value() (driver.Value, error) {
+	if dob.IsZero() {
+		return nil, nil
+	}
+	return dob.Time, nil
+}
+
+// scanTime converts a database value to a time, parsing strings with the provided layout
+func scanTime(src interface{}, layout string) (time.Time, error) {
+	switch v := src.(type) {
+	case nil:
+		return time.Time{}, nil
+	case time.Time:
+		return v, nil
+	case string:
+		if v == "" {
+			return time.Time{}, nil
+		}
+		return time.Parse(layout, v)
+	case []byte:
+		if len(v) == 0 {
+			return time.Time{}, nil
+		}
+		return time.Parse(layout, string(v))
+	default:
+		return time.Time{}, fmt.Errorf("cannot scan %T into a date", src)
+	}
+}
+
 type strint int
 
 func (v *strint) UnmarshalJSON(b []byte) error {
@@ -59,6 +172,40 @@ func (v *strint) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals the value as a quoted string, the way CH returns it
+func (v strint) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.Itoa(int(v)))
+}
+
+func (v *strint) UnmarshalText(b []byte) error {
+	return v.UnmarshalJSON(b)
+}
+
+func (v strint) MarshalText() ([]byte, error) {
+	return []byte(strconv.Itoa(int(v))), nil
+}
+
+func (v *strint) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case nil:
+		*v = 0
+		return nil
+	case int64:
+		*v = strint(s)
+		return nil
+	case string:
+		return v.UnmarshalText([]byte(s))
+	case []byte:
+		return v.UnmarshalText(s)
+	default:
+		return fmt.Errorf("cannot scan %T into an integer", src)
+	}
+}
+
+func (v strint) Value() (driver.Value, error) {
+	return int64(v), nil
+}
+
 func (v *strint) Int() int {
 	return int(*v)
 }
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStrintMarshal(t *testing.T) {
+	var v strint
+	for _, in := range []string{`"42"`, `42`} {
+		if err := json.Unmarshal([]byte(in), &v); err != nil {
+			t.Fatalf("expected to pass, but got: %v", err)
+		}
+		if got, expected := v.Int(), 42; got != expected {
+			t.Errorf("expected %d for %s, but got %d", expected, in, got)
+		}
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if got, expected := string(b), `"42"`; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	b, err = v.MarshalText()
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if got, expected := string(b), "42"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if err := json.Unmarshal([]byte(`"forty-two"`), &v); err == nil {
+		t.Errorf("expected an error for a non-numeric string")
+	}
+}
+
+func TestStrintSQL(t *testing.T) {
+	var v strint
+	for _, src := range []interface{}{int64(42), "42", []byte("42")} {
+		v = 0
+		if err := v.Scan(src); err != nil {
+			t.Fatalf("expected to pass, but got: %v", err)
+		}
+		if got, expected := v.Int(), 42; got != expected {
+			t.Errorf("expected %d for %T, but got %d", expected, src, got)
+		}
+	}
+
+	val, err := v.Value()
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if got, expected := val, int64(42); got != expected {
+		t.Errorf("expected %v, but got %v", expected, got)
+	}
+
+	if err := v.Scan(nil); err != nil || v != 0 {
+		t.Errorf("expected NULL to scan to 0, but got %d, %v", v, err)
+	}
+
+	if err := v.Scan(4.2); err == nil {
+		t.Errorf("expected an error for a float")
+	}
+}
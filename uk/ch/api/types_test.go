@@ -0,0 +1,141 @@
+package api_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	ch "github.com/appinesshq/globire-go/uk/ch/api"
+)
+
+func TestChDateMarshalJSON(t *testing.T) {
+	in := `{"company_number":"12345678","date_of_creation":"2019-06-25","accounts":{"next_accounts":{"due_on":"2021-06-25"}}}`
+
+	var c ch.Company
+	if err := json.Unmarshal([]byte(in), &c); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	out := string(b)
+
+	if got, expected := strings.Contains(out, `"date_of_creation":"2019-06-25"`), true; got != expected {
+		t.Errorf("expected date_of_creation in %s", out)
+	}
+
+	// Omitted with omitzero as of Go 1.24, or null before
+	if got, expected := strings.Contains(out, `"date_of_cessation":"`), false; got != expected {
+		t.Errorf("expected no zero date_of_cessation in %s", out)
+	}
+
+	var c2 ch.Company
+	if err := json.Unmarshal(b, &c2); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	if got, expected := c2.Accounts.NextAccounts.DueOn, c.Accounts.NextAccounts.DueOn; !got.Equal(expected.Time) {
+		t.Errorf("expected %v, but got %v", expected, got)
+	}
+}
+
+func TestChDateSQL(t *testing.T) {
+	var d ch.ChDate
+
+	v, err := d.Value()
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if v != nil {
+		t.Errorf("expected zero date to be stored as NULL, but got %v", v)
+	}
+
+	if err := d.Scan("2019-06-25"); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if got, expected := d.Time, time.Date(2019, 6, 25, 0, 0, 0, 0, time.UTC); !got.Equal(expected) {
+		t.Errorf("expected %v, but got %v", expected, got)
+	}
+
+	b, err := d.MarshalText()
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if got, expected := string(b), "2019-06-25"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+}
+
+func TestDateOfBirthMarshal(t *testing.T) {
+	var dob ch.DateOfBirth
+	if err := json.Unmarshal([]byte(`"1977-12-01T00:00:00"`), &dob); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if got, expected := dob.Time, time.Date(1977, 12, 1, 0, 0, 0, 0, time.UTC); !got.Equal(expected) {
+		t.Errorf("expected %v, but got %v", expected, got)
+	}
+
+	b, err := json.Marshal(dob)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if got, expected := string(b), `"1977-12-01T00:00:00"`; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	b, err = json.Marshal(ch.DateOfBirth{})
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if got, expected := string(b), "null"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	b, err = dob.MarshalText()
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if got, expected := string(b), "1977-12-01T00:00:00"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+}
+
+func TestDateOfBirthSQL(t *testing.T) {
+	var dob ch.DateOfBirth
+
+	v, err := dob.Value()
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if v != nil {
+		t.Errorf("expected zero date of birth to be stored as NULL, but got %v", v)
+	}
+
+	for _, src := range []interface{}{"1977-12-01T00:00:00", []byte("1977-12-01T00:00:00"), time.Date(1977, 12, 1, 0, 0, 0, 0, time.UTC)} {
+		if err := dob.Scan(src); err != nil {
+			t.Fatalf("expected to pass, but got: %v", err)
+		}
+		if got, expected := dob.Time, time.Date(1977, 12, 1, 0, 0, 0, 0, time.UTC); !got.Equal(expected) {
+			t.Errorf("expected %v for %T, but got %v", expected, src, got)
+		}
+	}
+
+	v, err = dob.Value()
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if got, ok := v.(time.Time); !ok || !got.Equal(dob.Time) {
+		t.Errorf("expected %v, but got %v", dob.Time, v)
+	}
+
+	if err := dob.Scan(nil); err != nil || !dob.IsZero() {
+		t.Errorf("expected NULL to scan to a zero date of birth, but got %v, %v", dob, err)
+	}
+
+	if err := dob.Scan(42); err == nil {
+		t.Errorf("expected an error for an integer")
+	}
+}
@@ -0,0 +1,315 @@
+// Package ubo resolves the ultimate beneficial owners of a company, by following the chains of
+// corporate persons with significant control and computing the effective ownership of every
+// natural person at the end of a chain.
+package ubo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/appinesshq/globire-go/uk/ch/api"
+	"github.com/pkg/errors"
+)
+
+// DefaultMaxDepth is the default maximum length of a chain of corporate PSCs
+const DefaultMaxDepth = 10
+
+// IssueKind represents a reason why (part of) the ownership couldn't be resolved
+type IssueKind string
+
+const (
+	// Cycle is reported when a company is (indirectly) its own PSC
+	Cycle IssueKind = "cycle"
+
+	// ForeignEntity is reported for corporate PSCs which aren't registered at Companies House,
+	// so their own PSCs can't be retrieved
+	ForeignEntity IssueKind = "foreign-entity"
+
+	// SuperSecure is reported for PSCs whose details are protected
+	SuperSecure IssueKind = "super-secure"
+
+	// NoPSC is reported for companies in the chain without active PSCs
+	NoPSC IssueKind = "no-psc"
+
+	// UnknownOwnership is reported for PSCs which don't hold shares, e.g. controlling through voting rights only
+	UnknownOwnership IssueKind = "unknown-ownership"
+
+	// MaxDepth is reported when a chain is longer than the maximum depth of the resolver
+	MaxDepth IssueKind = "max-depth"
+
+	// RequestFailed is reported for companies in the chain which couldn't be retrieved
+	RequestFailed IssueKind = "request-failed"
+)
+
+// Link is a single step in an ownership chain: a PSC of a company
+type Link struct {
+	CompanyNumber string
+	PSCName       string
+	Min           float64 // Exclusive lower bound of the share band
+	Max           float64 // Inclusive upper bound of the share band
+}
+
+// Path is an ownership chain, starting at the resolved company
+type Path []Link
+
+// String returns a human readable representation of the path
+func (p Path) String() string {
+	parts := make([]string, len(p))
+	for i, l := range p {
+		parts[i] = fmt.Sprintf("%s (%s: %.0f-%.0f%%)", l.PSCName, l.CompanyNumber, l.Min*100, l.Max*100)
+	}
+	return strings.Join(parts, " -> ")
+}
+
+// share returns the effective share band and the estimate based on the midpoints of the bands
+func (p Path) share() (min, max, estimate float64) {
+	min, max, estimate = 1, 1, 1
+	for _, l := range p {
+		min *= l.Min
+		max *= l.Max
+		estimate *= (l.Min + l.Max) / 2
+	}
+	return
+}
+
+// Owner is a natural person (or a legal person like a government body) at the end of one or
+// more ownership chains
+type Owner struct {
+	Name        string
+	Kind        api.PSCKind
+	DateOfBirth api.OfficerDateOfBirth
+	Nationality string
+
+	// The effective ownership of the resolved company, summed over all paths.
+	// The true share is more than Min and at most Max.
+	Min      float64
+	Max      float64
+	Estimate float64
+
+	Paths []Path
+}
+
+// Issue represents a chain which couldn't be resolved to natural persons
+type Issue struct {
+	Kind          IssueKind
+	CompanyNumber string // Company of which the PSC couldn't be resolved
+	Name          string
+	Detail        string
+	Min           float64 // Effective share band of the unresolved chain
+	Max           float64
+	Path          Path
+}
+
+// Result contains the resolved owners and issues of a company
+type Result struct {
+	CompanyNumber string
+	Owners        []Owner
+	Issues        []Issue
+}
+
+// Above returns the owners whose effective ownership certainly exceeds the threshold,
+// e.g. 0.25 for the reporting threshold of the AML rules
+func (r *Result) Above(threshold float64) []Owner {
+	var res []Owner
+	for _, o := range r.Owners {
+		if o.Min >= threshold {
+			res = append(res, o)
+		}
+	}
+	return res
+}
+
+// Possibly returns the owners whose effective ownership may exceed the threshold, depending
+// on the actual shares within the bands
+func (r *Result) Possibly(threshold float64) []Owner {
+	var res []Owner
+	for _, o := range r.Owners {
+		if o.Max > threshold {
+			res = append(res, o)
+		}
+	}
+	return res
+}
+
+// Resolver resolves ultimate beneficial owners using the Companies House API.
+// A Resolver is safe for concurrent use, as long as its fields aren't changed.
+type Resolver struct {
+	API      *api.API
+	MaxDepth int // DefaultMaxDepth is used when it's 0
+}
+
+// New returns a Resolver with the default maximum depth
+func New(a *api.API) *Resolver {
+	return &Resolver{API: a, MaxDepth: DefaultMaxDepth}
+}
+
+// Resolve returns the ultimate beneficial owners of a company
+func (r *Resolver) Resolve(ctx context.Context, companyNumber string) (*Result, error) {
+	res := &Result{CompanyNumber: companyNumber}
+	rs := &resolution{Resolver: r, res: res, maxDepth: r.MaxDepth, owners: make(map[string]*Owner), pscs: make(map[string][]api.PSC)}
+	if rs.maxDepth <= 0 {
+		rs.maxDepth = DefaultMaxDepth
+	}
+
+	pscs, err := rs.active(companyNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rs.walk(ctx, companyNumber, pscs, nil); err != nil {
+		return nil, err
+	}
+
+	// Overlapping bands of multiple paths can add up to more than the whole company
+	for _, o := range rs.owners {
+		o.Min = math.Min(o.Min, 1)
+		o.Max = math.Min(o.Max, 1)
+		o.Estimate = math.Min(o.Estimate, 1)
+		res.Owners = append(res.Owners, *o)
+	}
+	sort.SliceStable(res.Owners, func(i, j int) bool { return res.Owners[i].Estimate > res.Owners[j].Estimate })
+
+	return res, nil
+}
+
+// resolution contains the state of a single call of Resolve
+type resolution struct {
+	*Resolver
+	res      *Result
+	maxDepth int
+	owners   map[string]*Owner
+	pscs     map[string][]api.PSC // Active PSCs by company number
+}
+
+func (r *resolution) walk(ctx context.Context, companyNumber string, pscs []api.PSC, path Path) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	issue := func(kind IssueKind, name, detail string, p Path) {
+		min, max, _ := p.share()
+		r.res.Issues = append(r.res.Issues, Issue{Kind: kind, CompanyNumber: companyNumber, Name: name, Detail: detail, Min: min, Max: max, Path: p})
+	}
+
+	if len(pscs) == 0 {
+		issue(NoPSC, "", "company has no active persons with significant control", path)
+		return nil
+	}
+
+	for _, p := range pscs {
+		if p.Kind == api.SuperSecurePSC {
+			issue(SuperSecure, p.Description, "details are protected", path)
+			continue
+		}
+
+		min, max, ok := p.Ownership()
+		if !ok {
+			issue(UnknownOwnership, p.Name, "controls without holding shares", path)
+			continue
+		}
+
+		next := append(append(Path{}, path...), Link{CompanyNumber: companyNumber, PSCName: p.Name, Min: min, Max: max})
+
+		if p.Kind != api.CorporatePSC {
+			addOwner(r.owners, p, next)
+			continue
+		}
+
+		number, ok := p.Identification.UKCompanyNumber()
+		if !ok {
+			issue(ForeignEntity, p.Name, fmt.Sprintf("registered in %s", strings.TrimSpace(p.Identification.PlaceRegistered+" "+p.Identification.CountryRegistered)), next)
+			continue
+		}
+
+		if inPath(next, number) {
+			issue(Cycle, p.Name, fmt.Sprintf("%s is already part of the chain", number), next)
+			continue
+		}
+
+		if len(next) >= r.maxDepth {
+			issue(MaxDepth, p.Name, fmt.Sprintf("chain is longer than %d companies", r.maxDepth), next)
+			continue
+		}
+
+		sub, err := r.active(number)
+		if err != nil {
+			issue(RequestFailed, p.Name, err.Error(), next)
+			continue
+		}
+
+		if err := r.walk(ctx, number, sub, next); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// active returns the active PSCs of a company. Results are cached for the resolution, so companies
+// which are part of multiple chains are only retrieved once.
+func (r *resolution) active(companyNumber string) ([]api.PSC, error) {
+	if pscs, ok := r.pscs[companyNumber]; ok {
+		return pscs, nil
+	}
+
+	c, err := r.API.GetCompany(companyNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var pscs []api.PSC
+	for start := 0; ; {
+		res, err := c.PersonsWithSignificantControl(api.ItemsPerPage(100), api.StartIndex(start))
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting persons with significant control of %s", companyNumber)
+		}
+
+		for _, p := range res.Items {
+			if !p.Ceased && p.CeasedOn.IsZero() {
+				pscs = append(pscs, p)
+			}
+		}
+
+		start += len(res.Items)
+		if len(res.Items) == 0 || start >= res.TotalResults {
+			break
+		}
+	}
+
+	r.pscs[companyNumber] = pscs
+	return pscs, nil
+}
+
+// addOwner adds the path to the owner it ends with. The same person is listed with a different
+// PSC ID for each company, so owners are matched on name and date of birth.
+func addOwner(owners map[string]*Owner, p api.PSC, path Path) {
+	name := strings.ToLower(strings.Join(strings.Fields(p.NameElements.Forename+" "+p.NameElements.Surname), " "))
+	if name == "" {
+		name = strings.ToLower(strings.Join(strings.Fields(p.Name), " "))
+	}
+	key := fmt.Sprintf("%s|%s|%d-%d", p.Kind, name, p.DateOfBirth.Year, p.DateOfBirth.Month)
+
+	o, ok := owners[key]
+	if !ok {
+		o = &Owner{Name: p.Name, Kind: p.Kind, DateOfBirth: p.DateOfBirth, Nationality: p.Nationality}
+		owners[key] = o
+	}
+
+	min, max, estimate := path.share()
+	o.Min += min
+	o.Max += max
+	o.Estimate += estimate
+	o.Paths = append(o.Paths, path)
+}
+
+func inPath(p Path, companyNumber string) bool {
+	for _, l := range p {
+		if l.CompanyNumber == companyNumber {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,186 @@
+package ubo_test
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	ch "github.com/appinesshq/globire-go/uk/ch/api"
+	"github.com/appinesshq/globire-go/uk/ch/api/tests"
+	"github.com/appinesshq/globire-go/uk/ch/api/ubo"
+)
+
+func TestResolve(t *testing.T) {
+	api, err := ch.New("12345")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	ts := tests.NewMockServer()
+	defer ts.Close()
+	api.URL, err = url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	res, err := ubo.New(api).Resolve(context.Background(), "12345678")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	if got, expected := len(res.Issues), 0; got != expected {
+		t.Fatalf("expected %d issues, but got %d: %+v", expected, got, res.Issues)
+	}
+
+	// Test Person holds 25-50% directly, and 75-100% of PARENT LTD which holds 50-75%
+	if got, expected := len(res.Owners), 1; got != expected {
+		t.Fatalf("expected %d owner, but got %d: %+v", expected, got, res.Owners)
+	}
+	o := res.Owners[0]
+
+	if got, expected := len(o.Paths), 2; got != expected {
+		t.Errorf("expected %d paths, but got %d", expected, got)
+	}
+
+	if got, expected := o.Min, 0.625; math.Abs(got-expected) > 1e-9 {
+		t.Errorf("expected minimum %v, but got %v", expected, got)
+	}
+
+	if got, expected := o.Max, 1.0; got != expected {
+		t.Errorf("expected maximum %v, but got %v", expected, got)
+	}
+
+	if got, expected := len(res.Above(0.25)), 1; got != expected {
+		t.Errorf("expected %d owners above 25%%, but got %d", expected, got)
+	}
+
+	if got, expected := len(res.Above(0.75)), 0; got != expected {
+		t.Errorf("expected %d owners certainly above 75%%, but got %d", expected, got)
+	}
+
+	if got, expected := len(res.Possibly(0.75)), 1; got != expected {
+		t.Errorf("expected %d owners possibly above 75%%, but got %d", expected, got)
+	}
+
+	// The zero value of MaxDepth uses the default
+	res, err = (&ubo.Resolver{API: api}).Resolve(context.Background(), "12345678")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if got, expected := len(res.Issues), 0; got != expected {
+		t.Errorf("expected %d issues, but got %d: %+v", expected, got, res.Issues)
+	}
+	if got, expected := len(res.Owners), 1; got != expected {
+		t.Errorf("expected %d owner, but got %d: %+v", expected, got, res.Owners)
+	}
+}
+
+func TestResolveConcurrent(t *testing.T) {
+	api, err := ch.New("12345")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	ts := tests.NewMockServer()
+	defer ts.Close()
+	api.URL, err = url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	r := ubo.New(api)
+	numbers := []string{"12345678", "87654321", "12345678", "12345678"}
+	results := make([]*ubo.Result, len(numbers))
+	errs := make([]error, len(numbers))
+
+	var wg sync.WaitGroup
+	for i, n := range numbers {
+		wg.Add(1)
+		go func(i int, n string) {
+			defer wg.Done()
+			results[i], errs[i] = r.Resolve(context.Background(), n)
+		}(i, n)
+	}
+	wg.Wait()
+
+	for i, n := range numbers {
+		if errs[i] != nil {
+			t.Fatalf("expected to pass, but got: %v", errs[i])
+		}
+
+		expected, err := r.Resolve(context.Background(), n)
+		if err != nil {
+			t.Fatalf("expected to pass, but got: %v", err)
+		}
+		if got := results[i]; len(got.Owners) != len(expected.Owners) || len(got.Issues) != len(expected.Issues) {
+			t.Errorf("expected %+v for %s, but got %+v", expected, n, got)
+		}
+	}
+}
+
+func TestResolveIssues(t *testing.T) {
+	data := map[string]string{
+		"/company/00000001": `{"company_number": "00000001"}`,
+		"/company/00000002": `{"company_number": "00000002"}`,
+		"/company/00000001/persons-with-significant-control": `{"total_results": 3, "items": [
+			{"kind": "corporate-entity-person-with-significant-control", "name": "B LTD", "natures_of_control": ["ownership-of-shares-50-to-75-percent"],
+			 "identification": {"place_registered": "Companies House", "registration_number": "2"}},
+			{"kind": "corporate-entity-person-with-significant-control", "name": "FOREIGN BV", "natures_of_control": ["ownership-of-shares-25-to-50-percent"],
+			 "identification": {"country_registered": "Netherlands", "registration_number": "12345678"}},
+			{"kind": "super-secure-person-with-significant-control", "description": "super-secure-persons-with-significant-control"}
+		]}`,
+		"/company/00000002/persons-with-significant-control": `{"total_results": 1, "items": [
+			{"kind": "corporate-entity-person-with-significant-control", "name": "A LTD", "natures_of_control": ["ownership-of-shares-75-to-100-percent"],
+			 "identification": {"place_registered": "Companies House", "registration_number": "1"}}
+		]}`,
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		d, ok := data[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(d))
+	}))
+	defer ts.Close()
+
+	api, err := ch.New("12345")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	api.URL, _ = url.Parse(ts.URL)
+
+	res, err := ubo.New(api).Resolve(context.Background(), "00000001")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	if got, expected := len(res.Owners), 0; got != expected {
+		t.Errorf("expected %d owners, but got %d", expected, got)
+	}
+
+	issues := make(map[ubo.IssueKind]ubo.Issue)
+	for _, i := range res.Issues {
+		issues[i.Kind] = i
+	}
+
+	for _, k := range []ubo.IssueKind{ubo.Cycle, ubo.ForeignEntity, ubo.SuperSecure} {
+		if _, ok := issues[k]; !ok {
+			t.Errorf("expected a %q issue, but got %+v", k, res.Issues)
+		}
+	}
+
+	if got := issues[ubo.ForeignEntity].Detail; !strings.Contains(got, "Netherlands") {
+		t.Errorf("expected the country of registration in %q", got)
+	}
+
+	if got, expected := issues[ubo.Cycle].Max, 0.75; math.Abs(got-expected) > 1e-9 {
+		t.Errorf("expected maximum %v, but got %v", expected, got)
+	}
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/appinesshq/globire-go/uk/ch/api/enum"
+)
+
+// UnknownValueError is returned by the Validate methods of the enum types for values which aren't known codes
+type UnknownValueError struct {
+	Section string // The section of the CH enums, e.g. company_status, or psc_kind for PSCKind
+	Value   string
+}
+
+// Error implements the Error interface
+func (err *UnknownValueError) Error() string {
+	return fmt.Sprintf("unknown %s %q", strings.NewReplacer("_", " ", "-", " ").Replace(err.Section), err.Value)
+}
+
+// IsUnknownValueError returns true if the provided error is of type UnknownValueError, as well as the asserted error.
+func IsUnknownValueError(err error) (bool, *UnknownValueError) {
+	e, ok := err.(*UnknownValueError)
+	return ok, e
+}
+
+// validate returns an UnknownValueError if the value isn't a key of the section
+func validate(e enum.ENUM, section, value string) error {
+	if !e.Has(section, value) {
+		return &UnknownValueError{Section: section, Value: value}
+	}
+	return nil
+}
+
+// validateOptional is like validate, but accepts an empty value for fields which may be missing
+func validateOptional(e enum.ENUM, section, value string) error {
+	if value == "" {
+		return nil
+	}
+	return validate(e, section, value)
+}
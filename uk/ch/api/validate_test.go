@@ -0,0 +1,91 @@
+package api_test
+
+import (
+	"testing"
+
+	ch "github.com/appinesshq/globire-go/uk/ch/api"
+	"github.com/appinesshq/globire-go/uk/ch/api/enum"
+)
+
+func TestValidate(t *testing.T) {
+	if err := ch.CompanyStatus("active").Validate(); err != nil {
+		t.Errorf("expected to pass, but got: %v", err)
+	}
+
+	err := ch.CompanyStatus("actve").Validate()
+	ok, e := ch.IsUnknownValueError(err)
+	if !ok {
+		t.Fatalf("expected an UnknownValueError, but got: %v", err)
+	}
+	if got, expected := e.Error(), `unknown company status "actve"`; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if err := ch.OfficerRole("director").Validate(); err != nil {
+		t.Errorf("expected to pass, but got: %v", err)
+	}
+	if err := ch.SICCode("62012").Validate(); err != nil {
+		t.Errorf("expected to pass, but got: %v", err)
+	}
+	if err := ch.ChargeStatus("outstanding").Validate(); err != nil {
+		t.Errorf("expected to pass, but got: %v", err)
+	}
+	if err := ch.CompanyType("").Validate(); err == nil {
+		t.Errorf("expected an empty value to fail")
+	}
+
+	// Optional fields may be missing
+	if err := ch.CompanyStatusDetail("").Validate(); err != nil {
+		t.Errorf("expected to pass, but got: %v", err)
+	}
+	if err := ch.CompanyStatusDetail("unknown-detail").Validate(); err == nil {
+		t.Errorf("expected an unknown optional value to fail")
+	}
+}
+
+func TestValidatePSCKind(t *testing.T) {
+	if err := ch.CorporatePSC.Validate(); err != nil {
+		t.Errorf("expected to pass, but got: %v", err)
+	}
+
+	err := ch.PSCKind("person").Validate()
+	ok, e := ch.IsUnknownValueError(err)
+	if !ok {
+		t.Fatalf("expected an UnknownValueError, but got: %v", err)
+	}
+	if got, expected := e.Error(), `unknown psc kind "person"`; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if err := ch.PSCKind("").Validate(); err == nil {
+		t.Errorf("expected an empty value to fail")
+	}
+}
+
+func TestEnumLookup(t *testing.T) {
+	if v, ok := enum.Constants.Lookup("company_status", "active"); !ok || v != "Active" {
+		t.Errorf("expected \"Active\", but got %q", v)
+	}
+
+	if enum.Constants.Has("company_status", "actve") {
+		t.Errorf("expected an unknown key not to exist")
+	}
+
+	keys := enum.Constants.Keys("company_status")
+	if len(keys) == 0 {
+		t.Fatalf("expected company statuses")
+	}
+	for i := 1; i < len(keys); i++ {
+		if keys[i-1] > keys[i] {
+			t.Errorf("expected sorted keys, but got %v", keys)
+			break
+		}
+	}
+
+	if k, ok := enum.Constants.Reverse("company_status", " ACTIVE "); !ok || k != "active" {
+		t.Errorf("expected \"active\", but got %q", k)
+	}
+	if _, ok := enum.Constants.Reverse("company_status", "Unknown"); ok {
+		t.Errorf("expected an unknown description not to exist")
+	}
+}
@@ -0,0 +1,107 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/appinesshq/globire-go/uk/ch/api/diff"
+	"github.com/pkg/errors"
+)
+
+// Notifier receives the changes detected by a Watcher
+type Notifier interface {
+	Notify(ctx context.Context, changes []diff.Change) error
+}
+
+// NotifyError is returned by Watcher.Check if notifiers failed to receive the changes
+type NotifyError struct {
+	Errors []error // The errors of the failed notifiers, in the order of the notifiers
+}
+
+// Error implements the Error interface
+func (err *NotifyError) Error() string {
+	msgs := make([]string, len(err.Errors))
+	for i, e := range err.Errors {
+		msgs[i] = e.Error()
+	}
+	return "notifying changes: " + strings.Join(msgs, "; ")
+}
+
+// IsNotifyError returns true if the provided error is of type NotifyError, as well as the asserted error.
+func IsNotifyError(err error) (bool, *NotifyError) {
+	e, ok := err.(*NotifyError)
+	return ok, e
+}
+
+// NotifierFunc is an adapter to allow the use of ordinary functions as a Notifier
+type NotifierFunc func(ctx context.Context, changes []diff.Change) error
+
+// Notify implements the Notifier interface
+func (f NotifierFunc) Notify(ctx context.Context, changes []diff.Change) error {
+	return f(ctx, changes)
+}
+
+// Channel returns a Notifier which sends every change to the provided channel.
+// Sending blocks until the change is received or the context is done.
+func Channel(ch chan<- diff.Change) Notifier {
+	return NotifierFunc(func(ctx context.Context, changes []diff.Change) error {
+		for _, c := range changes {
+			select {
+			case ch <- c:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	})
+}
+
+// Webhook is a Notifier which POSTs the changes as JSON to a URL
+type Webhook struct {
+	URL    string
+	Header http.Header  // Extra headers, e.g. for authentication
+	Client *http.Client // Defaults to http.DefaultClient
+}
+
+// webhookPayload is the body of a webhook request
+type webhookPayload struct {
+	Changes []diff.Change `json:"changes"`
+}
+
+// Notify implements the Notifier interface.
+// Any response status outside of the 2xx range is returned as an error.
+func (wh *Webhook) Notify(ctx context.Context, changes []diff.Change) error {
+	b, err := json.Marshal(webhookPayload{Changes: changes})
+	if err != nil {
+		return errors.Wrap(err, "encoding changes")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wh.URL, bytes.NewReader(b))
+	if err != nil {
+		return errors.Wrap(err, "creating request")
+	}
+	for k, v := range wh.Header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := wh.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "http request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %v", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package watch
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/appinesshq/globire-go/uk/ch/api/diff"
+	"github.com/pkg/errors"
+)
+
+// Store persists the last known state of the watched companies
+type Store interface {
+	// Load returns the stored snapshot of a company, or nil if there is none
+	Load(companyNumber string) (*diff.Snapshot, error)
+
+	// Save stores the snapshot of a company, replacing the previous one
+	Save(companyNumber string, s *diff.Snapshot) error
+}
+
+// MemoryStore is a Store which keeps the snapshots in memory
+type MemoryStore struct {
+	mu        sync.RWMutex
+	snapshots map[string]*diff.Snapshot
+}
+
+// NewMemoryStore returns an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{snapshots: make(map[string]*diff.Snapshot)}
+}
+
+// Load implements the Store interface
+func (m *MemoryStore) Load(companyNumber string) (*diff.Snapshot, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.snapshots[companyNumber], nil
+}
+
+// Save implements the Store interface
+func (m *MemoryStore) Save(companyNumber string, s *diff.Snapshot) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.snapshots[companyNumber] = s
+	return nil
+}
+
+// FileStore is a Store which keeps a JSON file per company in a directory
+type FileStore struct {
+	Dir string
+}
+
+// NewFileStore returns a FileStore for the provided directory, which is created if it doesn't exist
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrap(err, "creating store directory")
+	}
+	return &FileStore{Dir: dir}, nil
+}
+
+func (f *FileStore) path(companyNumber string) string {
+	return filepath.Join(f.Dir, filepath.Base(companyNumber)+".json")
+}
+
+// Load implements the Store interface
+func (f *FileStore) Load(companyNumber string) (*diff.Snapshot, error) {
+	b, err := os.ReadFile(f.path(companyNumber))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "reading snapshot")
+	}
+
+	var s diff.Snapshot
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, errors.Wrap(err, "decoding snapshot")
+	}
+	return &s, nil
+}
+
+// Save implements the Store interface.
+// The snapshot is written to a temporary file first, so a failed write doesn't corrupt the stored state.
+func (f *FileStore) Save(companyNumber string, s *diff.Snapshot) error {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return errors.Wrap(err, "encoding snapshot")
+	}
+
+	tmp, err := os.CreateTemp(f.Dir, ".snapshot-*")
+	if err != nil {
+		return errors.Wrap(err, "creating snapshot")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "writing snapshot")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "writing snapshot")
+	}
+
+	return errors.Wrap(os.Rename(tmp.Name(), f.path(companyNumber)), "saving snapshot")
+}
@@ -0,0 +1,283 @@
+// Package watch monitors a list of companies for changes, by periodically polling the
+// Companies House API and comparing the result with the last known state.
+package watch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/appinesshq/globire-go/uk/ch/api"
+	"github.com/appinesshq/globire-go/uk/ch/api/diff"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultInterval is the default time between two polls of the watch-list
+	DefaultInterval = time.Hour
+
+	// DefaultRequestInterval is the default minimum time between two API requests.
+	// CH allows 600 requests per 5 minutes, which is one request every 500ms.
+	DefaultRequestInterval = 500 * time.Millisecond
+
+	pageSize = 100
+)
+
+// Watcher polls a watch-list of companies and notifies its notifiers of any changes
+type Watcher struct {
+	API       *api.API
+	Store     Store
+	Notifiers []Notifier
+
+	Interval        time.Duration // Time between two polls of the watch-list
+	RequestInterval time.Duration // Minimum time between two API requests
+
+	// Lists to retrieve and compare besides the company profile
+	Officers bool
+	PSCs     bool
+	Charges  bool
+
+	// OnError is called for errors which don't stop the watcher, e.g. a failed request for a company
+	OnError func(companyNumber string, err error)
+
+	mu        sync.Mutex
+	companies map[string]struct{}
+	last      time.Time
+}
+
+// New returns a Watcher which compares company profiles, officers, persons with significant
+// control and charges, using the default intervals
+func New(a *api.API, s Store, notifiers ...Notifier) *Watcher {
+	return &Watcher{
+		API:             a,
+		Store:           s,
+		Notifiers:       notifiers,
+		Interval:        DefaultInterval,
+		RequestInterval: DefaultRequestInterval,
+		Officers:        true,
+		PSCs:            true,
+		Charges:         true,
+		companies:       make(map[string]struct{}),
+	}
+}
+
+// Add adds companies to the watch-list
+func (w *Watcher) Add(companyNumbers ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.companies == nil {
+		w.companies = make(map[string]struct{})
+	}
+	for _, n := range companyNumbers {
+		w.companies[n] = struct{}{}
+	}
+}
+
+// Remove removes companies from the watch-list
+func (w *Watcher) Remove(companyNumbers ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, n := range companyNumbers {
+		delete(w.companies, n)
+	}
+}
+
+// Companies returns the watch-list in ascending order
+func (w *Watcher) Companies() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	res := make([]string, 0, len(w.companies))
+	for n := range w.companies {
+		res = append(res, n)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// Run polls the watch-list every Interval until the context is done
+func (w *Watcher) Run(ctx context.Context) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Poll(ctx); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll checks every company on the watch-list once.
+// Errors for individual companies are passed to OnError; only a done context stops the poll.
+func (w *Watcher) Poll(ctx context.Context) error {
+	for _, n := range w.Companies() {
+		if _, err := w.Check(ctx, n); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if w.OnError != nil {
+				w.OnError(n, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Check retrieves the current state of a company, compares it with the stored state,
+// notifies the notifiers of any changes and stores the new state.
+// The first check of a company only stores its state, without reporting changes.
+// A failing notifier doesn't stop the others, and the new state is stored regardless, so the
+// changes are delivered once; the failures are returned as a NotifyError.
+func (w *Watcher) Check(ctx context.Context, companyNumber string) ([]diff.Change, error) {
+	old, err := w.Store.Load(companyNumber)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading snapshot")
+	}
+
+	s, err := w.snapshot(ctx, companyNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []diff.Change
+	if old != nil {
+		changes = diff.Compare(*old, *s)
+	}
+
+	var failed []error
+	for _, n := range w.Notifiers {
+		if len(changes) == 0 {
+			break
+		}
+		if err := n.Notify(ctx, changes); err != nil {
+			failed = append(failed, err)
+		}
+	}
+
+	if err := w.Store.Save(companyNumber, s); err != nil {
+		return changes, errors.Wrap(err, "saving snapshot")
+	}
+
+	if len(failed) > 0 {
+		return changes, &NotifyError{Errors: failed}
+	}
+	return changes, nil
+}
+
+// snapshot retrieves the current state of a company from the API
+func (w *Watcher) snapshot(ctx context.Context, companyNumber string) (*diff.Snapshot, error) {
+	if err := w.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	// The company is requested even if the API has a Store, which would return the previous state
+	c, err := w.API.Fresh().GetCompany(companyNumber)
+	if err != nil {
+		return nil, err
+	}
+	// Lists are left empty rather than nil when the company has none, so the first item is reported
+	s := diff.Snapshot{Company: c}
+
+	if w.Officers {
+		s.Officers = []api.Officer{}
+	}
+	if w.Officers && c.Links.Officers != "" {
+		for {
+			if err := w.wait(ctx); err != nil {
+				return nil, err
+			}
+			var res api.Officers
+			if err := w.page(ctx, fmt.Sprintf("/company/%s/officers", c.CompanyNumber), len(s.Officers), &res); err != nil {
+				return nil, errors.Wrap(err, "getting officers")
+			}
+			s.Officers = append(s.Officers, res.Items...)
+			if len(res.Items) == 0 || len(s.Officers) >= res.TotalResults {
+				break
+			}
+		}
+	}
+
+	if w.PSCs {
+		s.PSCs = []api.PSC{}
+	}
+	if w.PSCs && c.Links.PersonsWithSignificantControl != "" {
+		for {
+			if err := w.wait(ctx); err != nil {
+				return nil, err
+			}
+			var res api.PSCs
+			if err := w.page(ctx, fmt.Sprintf("/company/%s/persons-with-significant-control", c.CompanyNumber), len(s.PSCs), &res); err != nil {
+				return nil, errors.Wrap(err, "getting persons with significant control")
+			}
+			s.PSCs = append(s.PSCs, res.Items...)
+			if len(res.Items) == 0 || len(s.PSCs) >= res.TotalResults {
+				break
+			}
+		}
+	}
+
+	if w.Charges {
+		s.Charges = []api.Charge{}
+	}
+	if w.Charges && c.HasCharges {
+		for {
+			if err := w.wait(ctx); err != nil {
+				return nil, err
+			}
+			var res api.Charges
+			if err := w.page(ctx, fmt.Sprintf("/company/%s/charges", c.CompanyNumber), len(s.Charges), &res); err != nil {
+				return nil, errors.Wrap(err, "getting charges")
+			}
+			s.Charges = append(s.Charges, res.Items...)
+			if len(res.Items) == 0 || len(s.Charges) >= res.TotalCount {
+				break
+			}
+		}
+	}
+
+	return &s, nil
+}
+
+// page requests a page of a list of the company, so cancelling ctx stops the paging
+func (w *Watcher) page(ctx context.Context, path string, start int, v interface{}) error {
+	params := url.Values{}
+	api.ItemsPerPage(pageSize)(&params)
+	api.StartIndex(start)(&params)
+	return w.API.Do(ctx, http.MethodGet, path, params, nil, v)
+}
+
+// wait blocks until RequestInterval has passed since the previous request, or the context is done
+func (w *Watcher) wait(ctx context.Context) error {
+	w.mu.Lock()
+	next := w.last.Add(w.RequestInterval)
+	now := time.Now()
+	if next.Before(now) {
+		next = now
+	}
+	w.last = next
+	w.mu.Unlock()
+
+	t := time.NewTimer(time.Until(next))
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
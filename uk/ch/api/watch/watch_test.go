@@ -0,0 +1,278 @@
+package watch_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	ch "github.com/appinesshq/globire-go/uk/ch/api"
+	"github.com/appinesshq/globire-go/uk/ch/api/diff"
+	"github.com/appinesshq/globire-go/uk/ch/api/tests"
+	"github.com/appinesshq/globire-go/uk/ch/api/watch"
+)
+
+func newAPI(t *testing.T) *ch.API {
+	api, err := ch.New("12345")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	ts := tests.NewMockServer()
+	t.Cleanup(ts.Close)
+	api.URL, err = url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	return api
+}
+
+func TestWatcherPoll(t *testing.T) {
+	store := watch.NewMemoryStore()
+	store.Save("12345678", &diff.Snapshot{
+		Company:  &ch.Company{CompanyNumber: "12345678", Name: "OLD TEST LTD", CompanyStatus: "active"},
+		Officers: []ch.Officer{},
+		PSCs:     []ch.PSC{},
+		Charges:  []ch.Charge{},
+	})
+
+	var received []diff.Change
+	wh := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p struct {
+			Changes []diff.Change `json:"changes"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = append(received, p.Changes...)
+	}))
+	defer wh.Close()
+
+	events := make(chan diff.Change, 10)
+	w := watch.New(newAPI(t), store, watch.Channel(events), &watch.Webhook{URL: wh.URL})
+	w.RequestInterval = 0
+	w.OnError = func(n string, err error) { t.Errorf("expected to pass, but got: %v", err) }
+	w.Add("12345678")
+
+	if err := w.Poll(context.Background()); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	close(events)
+
+	expected := []string{
+		"name-changed TEST LTD",
+		"registered-office-moved Office 1, 15 Test Road, Test Town, TS1 2TS, United Kingdom",
+		"sic-codes-changed 58290, 62012",
+		"officer-appointed PERSON, Test",
+		"psc-notified Mr Test Person",
+		"psc-notified PARENT LTD",
+	}
+	var delivered []diff.Change
+	for c := range events {
+		delivered = append(delivered, c)
+	}
+	for name, changes := range map[string][]diff.Change{"channel": delivered, "webhook": received} {
+		var got []string
+		for _, c := range changes {
+			if c.CompanyNumber != "12345678" {
+				t.Errorf("expected the %s to receive changes of 12345678, but got %q", name, c.CompanyNumber)
+			}
+			got = append(got, string(c.Kind)+" "+c.New)
+		}
+		if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+			t.Errorf("expected the %s to receive %q, but got %q", name, expected, got)
+		}
+	}
+
+	s, _ := store.Load("12345678")
+	if got, expected := s.Company.Name, "TEST LTD"; got != expected {
+		t.Errorf("expected stored name %q, but got %q", expected, got)
+	}
+
+	// Nothing changed since the previous poll
+	changes, err := w.Check(context.Background(), "12345678")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if got, expected := len(changes), 0; got != expected {
+		t.Errorf("expected %d changes, but got %d", expected, got)
+	}
+}
+
+func TestWatcherNotifyError(t *testing.T) {
+	store := watch.NewMemoryStore()
+	store.Save("12345678", &diff.Snapshot{
+		Company: &ch.Company{CompanyNumber: "12345678", Name: "OLD TEST LTD", CompanyStatus: "active"},
+	})
+
+	var received int
+	failing := watch.NotifierFunc(func(ctx context.Context, changes []diff.Change) error {
+		return errors.New("unavailable")
+	})
+	counting := watch.NotifierFunc(func(ctx context.Context, changes []diff.Change) error {
+		received += len(changes)
+		return nil
+	})
+
+	w := watch.New(newAPI(t), store, failing, counting)
+	w.RequestInterval = 0
+	w.Officers, w.PSCs, w.Charges = false, false, false
+
+	changes, err := w.Check(context.Background(), "12345678")
+	ok, e := watch.IsNotifyError(err)
+	if !ok {
+		t.Fatalf("expected a NotifyError, but got: %v", err)
+	}
+	if got, expected := e.Error(), "notifying changes: unavailable"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	// The other notifiers still receive the changes
+	if got, expected := received, len(changes); got != expected || got == 0 {
+		t.Errorf("expected %d changes, but got %d", expected, got)
+	}
+
+	// The new state is stored, so the changes aren't sent again
+	changes, err = w.Check(context.Background(), "12345678")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	if got, expected := len(changes), 0; got != expected {
+		t.Errorf("expected %d changes, but got %d", expected, got)
+	}
+}
+
+func TestWatcherCheckCancel(t *testing.T) {
+	stop := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/officers") {
+			// Don't respond until the client gives up, or the test ends
+			select {
+			case <-r.Context().Done():
+			case <-stop:
+			}
+			return
+		}
+		w.Write([]byte(`{"company_number": "12345678", "links": {"officers": "/company/12345678/officers"}}`))
+	}))
+	defer ts.Close()
+	defer close(stop)
+
+	api, err := ch.New("12345")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+	api.URL, err = url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	w := watch.New(api, watch.NewMemoryStore())
+	w.RequestInterval = 0
+	w.PSCs, w.Charges = false, false
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := w.Check(ctx, "12345678")
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Errorf("expected the cancelled check to fail")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected cancelling the context to stop getting officers")
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	store, err := watch.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	s, err := store.Load("12345678")
+	if err != nil || s != nil {
+		t.Fatalf("expected no snapshot, but got %v, %v", s, err)
+	}
+
+	if err := store.Save("12345678", &diff.Snapshot{Company: &ch.Company{Name: "TEST LTD"}, Charges: []ch.Charge{}}); err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	s, err = store.Load("12345678")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	if got, expected := s.Company.Name, "TEST LTD"; got != expected {
+		t.Errorf("expected %q, but got %q", expected, got)
+	}
+
+	if s.Charges == nil || s.Officers != nil {
+		t.Errorf("expected empty and missing lists to be kept apart, but got %+v", s)
+	}
+}
+
+// cache is an api.Store in memory
+type cache map[string][]byte
+
+func (c cache) Load(number string, r ch.Resource, v interface{}) (bool, error) {
+	b, ok := c[number+"/"+string(r)]
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(b, v)
+}
+
+func (c cache) Save(number string, r ch.Resource, v interface{}) error {
+	b, err := json.Marshal(v)
+	c[number+"/"+string(r)] = b
+	return err
+}
+
+func TestWatcherStoredAPI(t *testing.T) {
+	old := &ch.Company{CompanyNumber: "12345678", Name: "OLD TEST LTD", CompanyStatus: "active"}
+
+	// The API has a stale copy of the company
+	api := newAPI(t)
+	c := cache{}
+	c.Save("12345678", ch.CompanyResource, old)
+	api.Store = c
+
+	store := watch.NewMemoryStore()
+	store.Save("12345678", &diff.Snapshot{Company: old})
+
+	w := watch.New(api, store)
+	w.RequestInterval = 0
+	w.Officers, w.PSCs, w.Charges = false, false, false
+
+	changes, err := w.Check(context.Background(), "12345678")
+	if err != nil {
+		t.Fatalf("expected to pass, but got: %v", err)
+	}
+
+	found := false
+	for _, change := range changes {
+		found = found || change.Kind == diff.NameChanged
+	}
+	if !found {
+		t.Errorf("expected the watcher to see the new name, but got %+v", changes)
+	}
+
+	var stored ch.Company
+	if _, err := c.Load("12345678", ch.CompanyResource, &stored); err != nil || stored.Name != "TEST LTD" {
+		t.Errorf("expected the store of the API to be updated, but got %q, %v", stored.Name, err)
+	}
+}